@@ -0,0 +1,50 @@
+package arena
+
+import (
+	"io"
+	"unsafe"
+)
+
+// PageSize is the alignment used by AllocPageAligned. / PageSize — выравнивание для AllocPageAligned.
+const PageSize = 4096
+
+// AllocAligned reserves n bytes whose address is a multiple of align.
+// AllocAligned выделяет n байт по адресу, кратному align.
+//
+// align must be a power of two. Like AllocBytes, the memory is not zeroed.
+// Panics if n < 0 or align is not a power of two. Returns nil for n == 0.
+func AllocAligned(a *Arena, n int, align int) []byte {
+	if n < 0 {
+		panic("arena: AllocAligned called with negative size")
+	}
+	if align <= 0 || align&(align-1) != 0 {
+		panic("arena: alignment must be a power of two")
+	}
+	if n == 0 {
+		return nil
+	}
+	ptr := a.allocAligned(n, align)
+	return unsafe.Slice((*byte)(ptr), n)
+}
+
+// AllocPageAligned reserves n bytes aligned to PageSize, suitable for O_DIRECT I/O.
+// AllocPageAligned выделяет n байт с выравниванием PageSize, пригодных для O_DIRECT.
+//
+// O_DIRECT also requires n and file offsets to be multiples of the device
+// block size; that is up to the caller.
+func AllocPageAligned(a *Arena, n int) []byte {
+	return AllocAligned(a, n, PageSize)
+}
+
+// ReadAtInto reads n bytes at off from r into a page-aligned arena buffer.
+// ReadAtInto читает n байт по смещению off из r в выровненный по странице буфер арены.
+//
+// The returned slice is trimmed to the bytes actually read; err follows io.ReaderAt.
+func ReadAtInto(a *Arena, r io.ReaderAt, off int64, n int) ([]byte, error) {
+	buf := AllocPageAligned(a, n)
+	if buf == nil {
+		return nil, nil
+	}
+	read, err := r.ReadAt(buf, off)
+	return buf[:read], err
+}
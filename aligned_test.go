@@ -0,0 +1,51 @@
+package arena
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestAllocAlignedAddress(t *testing.T) {
+	a := NewArena(64, 0)
+	for _, align := range []int{1, 8, 64, 512, PageSize} {
+		_ = a.AllocBytes(3) // misalign the cursor
+		b := AllocAligned(a, 100, align)
+		if len(b) != 100 || cap(b) != 100 {
+			t.Fatalf("align %d: unexpected len/cap %d/%d", align, len(b), cap(b))
+		}
+		if addr := uintptr(unsafe.Pointer(&b[0])); addr%uintptr(align) != 0 {
+			t.Fatalf("align %d: address 0x%x not aligned", align, addr)
+		}
+	}
+}
+
+func TestAllocAlignedPanicsOnInvalidArgs(t *testing.T) {
+	a := NewArena(64, 0)
+	mustPanic(t, "negative size", func() {
+		_ = AllocAligned(a, -1, 8)
+	})
+	mustPanic(t, "non power of two", func() {
+		_ = AllocAligned(a, 8, 24)
+	})
+	if b := AllocAligned(a, 0, 8); b != nil {
+		t.Fatalf("expected nil for n=0, got len=%d", len(b))
+	}
+}
+
+func TestReadAtInto(t *testing.T) {
+	a := NewArena(1024, 0)
+	src := strings.NewReader(strings.Repeat("x", 100) + "tail")
+
+	b, err := ReadAtInto(a, src, 100, PageSize)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF for short read, got %v", err)
+	}
+	if string(b) != "tail" {
+		t.Fatalf("unexpected data: %q", b)
+	}
+	if addr := uintptr(unsafe.Pointer(unsafe.SliceData(b))); addr%PageSize != 0 {
+		t.Fatalf("buffer 0x%x not page aligned", addr)
+	}
+}
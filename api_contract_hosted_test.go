@@ -0,0 +1,41 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"iter"
+	"net"
+	"testing"
+)
+
+// TestPublicAPIContractsHosted covers the API that is left out of TinyGo builds.
+func TestPublicAPIContractsHosted(t *testing.T) {
+	// Avro decoding.
+	var _ func([]byte) (*AvroSchema, error) = ParseAvroSchema
+	var _ func(*Arena, []byte) *AvroDecoder = NewAvroDecoder
+	var _ func(*AvroDecoder, *AvroSchema) (AvroValue, error) = (*AvroDecoder).Decode
+	var _ func(*AvroDecoder) (string, error) = (*AvroDecoder).ReadString
+	var _ func(*AvroDecoder) (int, error) = (*AvroDecoder).ReadBlockCount
+
+	// Hashed containers.
+	var _ func(*Arena, int, float64) *BloomFilter = NewBloomFilter
+	var _ func(*BloomFilter, []byte) = (*BloomFilter).Add
+	var _ func(*BloomFilter, [][]byte) = (*BloomFilter).AddBatch
+	var _ func(*BloomFilter, []byte) bool = (*BloomFilter).Test
+
+	// Connection arenas.
+	var _ func(net.Listener, *ArenaPool) *ArenaListener = NewArenaListener
+	var _ func(net.Conn, *ArenaPool) *ArenaConn = NewArenaConn
+	var _ func(net.Conn) *Arena = ConnArena
+	var _ net.Listener = (*ArenaListener)(nil)
+	var _ net.Conn = (*ArenaConn)(nil)
+	var _ func(*ArenaListener, func(*ArenaConn)) error = (*ArenaListener).Serve
+
+	// Iterator collection.
+	var _ func(*Arena, int) *Map[string, int] = NewMap[string, int]
+	var _ func(*Map[string, int], string, int) = (*Map[string, int]).Set
+	var _ func(*Map[string, int], string) (int, bool) = (*Map[string, int]).Get
+	var _ func(*Map[string, int], string) bool = (*Map[string, int]).Delete
+	var _ func(*Arena, iter.Seq[int]) []int = CollectSeq[int]
+	var _ func(*Arena, iter.Seq2[string, int]) *Map[string, int] = CollectSeq2[string, int]
+}
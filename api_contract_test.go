@@ -1,6 +1,13 @@
 package arena
 
-import "testing"
+import (
+	"encoding/base64"
+	"hash"
+	"image"
+	"io"
+	"iter"
+	"testing"
+)
 
 // TestPublicAPIContracts keeps compile-time checks for exported API signatures.
 // If any signature changes, this test fails to compile and signals a breaking change.
@@ -24,6 +31,88 @@ func TestPublicAPIContracts(t *testing.T) {
 	var _ func(*ArenaPool, *Arena) = (*ArenaPool).Put
 	var _ func(*ArenaPool) PoolMetricsSnapshot = (*ArenaPool).MetricsSnapshot
 
+	// Thrift decoding.
+	var _ func(*Arena, ThriftProtocol, []byte) *ThriftReader = NewThriftReader
+	var _ func(*ThriftReader, func(*ThriftReader) (int32, error)) ([]int32, error) = ThriftReadList[int32]
+	var _ func(*ThriftReader, func(*ThriftReader) (string, error), func(*ThriftReader) (int64, error)) ([]string, []int64, error) = ThriftReadMap[string, int64]
+	var _ func(*ThriftReader) (string, error) = (*ThriftReader).ReadString
+	var _ func(*ThriftReader) ([]byte, error) = (*ThriftReader).ReadBinary
+	var _ func(*ThriftReader, ThriftType) error = (*ThriftReader).Skip
+
+	// DNS parsing.
+	var _ func(*Arena, []byte) (*DNSMessage, error) = ParseDNSMessage
+	var _ func(*Arena, []byte, int) (string, int, error) = ParseDNSName
+
+	// Digest helpers.
+	var _ func(*Arena, hash.Hash) []byte = SumInto
+	var _ func(*Arena, hash.Hash) string = SumHex
+	var _ func(*Arena, hash.Hash, *base64.Encoding) string = SumBase64
+	var _ func(*Arena, []byte) string = HexString
+	var _ func(*Arena, *base64.Encoding, []byte) string = Base64String
+
+	// Aligned buffers.
+	var _ func(*Arena, int, int) []byte = AllocAligned
+	var _ func(*Arena, int) []byte = AllocPageAligned
+	var _ func(*Arena, io.ReaderAt, int64, int) ([]byte, error) = ReadAtInto
+
+	// Containers.
+	var _ func(*Arena) *SkipList[int, string] = NewSkipList[int, string]
+	var _ func(*Arena, func(int, int) int) *SkipList[int, string] = NewSkipListFunc[int, string]
+	var _ func(*SkipList[int, string], int, string) bool = (*SkipList[int, string]).Insert
+	var _ func(*SkipList[int, string], int) (string, bool) = (*SkipList[int, string]).Get
+	var _ func(*SkipList[int, string], int) SkipListIterator[int, string] = (*SkipList[int, string]).Seek
+	var _ func(*SkipList[int, string], int, int) iter.Seq2[int, string] = (*SkipList[int, string]).Range
+	var _ func(*Arena, int) *SparseSet = NewSparseSet
+	var _ func(*SparseSet, uint32) bool = (*SparseSet).Add
+	var _ func(*SparseSet, uint32) bool = (*SparseSet).Remove
+	var _ func(*SparseSet, uint32) bool = (*SparseSet).Contains
+	var _ func(*Arena, int, int) *GraphBuilder = NewGraphBuilder
+	var _ func(*GraphBuilder, uint32, uint32) = (*GraphBuilder).AddEdge
+	var _ func(*GraphBuilder) *Graph = (*GraphBuilder).Finalize
+	var _ func(*Graph, *Arena, uint32, func(uint32, int) bool) = (*Graph).BFS
+	var _ func(*Graph, *Arena, uint32, func(uint32) bool) = (*Graph).DFS
+	var _ func(*Arena, int) *Rope = NewRope
+	var _ func(*Rope, *Rope) = (*Rope).Concat
+	var _ func(*Rope) string = (*Rope).String
+	var _ io.WriterTo = (*Rope)(nil)
+	var _ io.StringWriter = (*Rope)(nil)
+
+	// Image buffers.
+	var _ func(*Arena, image.Rectangle) *image.RGBA = NewRGBA
+	var _ func(*Arena, image.Rectangle, int) *image.RGBA = NewRGBAAligned
+	var _ func(*Arena, image.Rectangle) *image.NRGBA = NewNRGBA
+	var _ func(*Arena, image.Rectangle, int) *image.NRGBA = NewNRGBAAligned
+	var _ func(*Arena, image.Rectangle) *image.Gray = NewGray
+	var _ func(*Arena, image.Rectangle, int) *image.Gray = NewGrayAligned
+
+	// Numeric buffers.
+	var _ func(*Arena, int, int, int) ([]float64, [][]float64) = MakeMatrixAligned
+
+	// Debug attribution.
+	var _ func() bool = DebugTracking
+	var _ func(*Arena, string) = (*Arena).SetOwnerLabel
+	var _ func(*Arena) []OwnerAllocStats = (*Arena).OwnerStats
+	var _ func(*ArenaPool) []OwnerAllocStats = (*ArenaPool).OwnerStats
+
+	// Memtable.
+	var _ func(*Arena, int, MemtableFlushFunc) *Memtable = NewMemtable
+	var _ func(*Memtable, string, string) error = (*Memtable).Put
+	var _ func(*Memtable, []byte, []byte) error = (*Memtable).PutBytes
+	var _ func(*Memtable, string) (string, bool) = (*Memtable).Get
+	var _ func(*Memtable) error = (*Memtable).Flush
+
+	// Failure injection.
+	var _ func() bool = FailpointsEnabled
+	var _ func(*Arena, *Failpoint) = (*Arena).SetFailpoint
+
+	// Safe publication.
+	var _ func(func(*Arena)) *Swapper[int] = NewSwapper[int]
+	var _ func(*Swapper[int], *Arena, *int) = (*Swapper[int]).Publish
+	var _ func(*Swapper[int], *Arena, func(*Arena) (*int, error)) error = (*Swapper[int]).Rebuild
+	var _ func(*Swapper[int]) *SwapperVersion[int] = (*Swapper[int]).Acquire
+	var _ func(*SwapperVersion[int]) *int = (*SwapperVersion[int]).Value
+	var _ func(*SwapperVersion[int]) = (*SwapperVersion[int]).Release
+
 	// Exported types presence.
 	var _ *PoolMetrics
 	var _ *PoolMetricsSnapshot
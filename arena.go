@@ -21,9 +21,11 @@ type Arena struct {
 	chunkIndex int // Current chunk index. / Индекс текущего чанка.
 
 	// --- cold path (touched only during growth / Reset) ---
-	chunkSize int      // Base chunk size. / Базовый размер чанка.
-	maxRetain int      // Retained memory after Reset. / Сколько памяти оставляем после Reset.
-	chunks    [][]byte // Chunk storage. / Набор чанков памяти.
+	chunkSize int        // Base chunk size. / Базовый размер чанка.
+	maxRetain int        // Retained memory after Reset. / Сколько памяти оставляем после Reset.
+	chunks    [][]byte   // Chunk storage. / Набор чанков памяти.
+	debug     debugState // Owner attribution, empty unless built with arenadebug. / Атрибуция владельцев, пусто без тега arenadebug.
+	fail      failState  // Injected failures, empty unless built with arenafailpoint. / Внедренные сбои, пусто без тега arenafailpoint.
 
 	_ [64]byte // false-sharing guard / защита от false sharing
 }
@@ -54,6 +56,9 @@ func NewArena(size int, maxRetained int) *Arena {
 func (a *Arena) Reset() {
 	a.chunkIndex = 0
 	a.offset = 0
+	if debugTracking {
+		a.debug.reset()
+	}
 
 	if len(a.chunks) == 0 {
 		firstChunk := make([]byte, a.chunkSize)
@@ -144,6 +149,10 @@ func (a *Arena) allocRaw(size int, align int) unsafe.Pointer {
 	if align <= 0 {
 		align = 1
 	}
+	if failpointsEnabled {
+		// Check before any state changes, chunk growth included. / Проверяем до любых изменений, включая рост чанков.
+		a.fail.check(size, false)
+	}
 
 	// align from unsafe.Alignof is power-of-two, so bit trick is safe. / align from unsafe.Alignof is a power of two, so bit trick is safe.
 	padding := (-a.offset) & (align - 1)
@@ -151,6 +160,9 @@ func (a *Arena) allocRaw(size int, align int) unsafe.Pointer {
 	if newOffset <= a.curEnd {
 		ptr := unsafe.Add(a.curStart, a.offset+padding)
 		a.offset = newOffset
+		if debugTracking {
+			a.debug.record(size)
+		}
 		return ptr
 	}
 
@@ -160,7 +172,44 @@ func (a *Arena) allocRaw(size int, align int) unsafe.Pointer {
 //go:noinline
 func (a *Arena) growAndAlloc(size int, align int) unsafe.Pointer {
 	a.ensure(size + align)
-	return a.allocRaw(size, align)
+	// The current chunk now has room for padding plus size. / Теперь в текущем чанке хватает места для выравнивания и блока.
+	padding := (-a.offset) & (align - 1)
+	ptr := unsafe.Add(a.curStart, a.offset+padding)
+	a.offset += padding + size
+	if debugTracking {
+		a.debug.record(size)
+	}
+	return ptr
+}
+
+// allocAligned is like allocRaw but aligns the absolute address, not the
+// offset, so alignments larger than the chunk's own alignment hold.
+// allocAligned выравнивает абсолютный адрес, а не смещение в чанке.
+func (a *Arena) allocAligned(size int, align int) unsafe.Pointer {
+	if size <= 0 {
+		return nil
+	}
+	if align <= 1 {
+		return a.allocRaw(size, 1)
+	}
+	if failpointsEnabled {
+		a.fail.check(size, false)
+	}
+
+	addr := uintptr(a.curStart) + uintptr(a.offset)
+	padding := int(-addr & uintptr(align-1))
+	if a.offset+padding+size > a.curEnd {
+		// A chunk with size+align-1 free bytes always fits the aligned block. / Чанк с size+align-1 свободными байтами всегда вмещает блок.
+		a.ensure(size + align - 1)
+		addr = uintptr(a.curStart) + uintptr(a.offset)
+		padding = int(-addr & uintptr(align-1))
+	}
+	ptr := unsafe.Add(a.curStart, a.offset+padding)
+	a.offset += padding + size
+	if debugTracking {
+		a.debug.record(size)
+	}
+	return ptr
 }
 
 func (a *Arena) ensure(size int) {
@@ -183,6 +232,9 @@ func (a *Arena) ensure(size int) {
 	if size > newSize {
 		newSize = size
 	}
+	if failpointsEnabled {
+		a.fail.check(newSize, true)
+	}
 	newChunk := make([]byte, newSize)
 	a.chunks = append(a.chunks, newChunk)
 	a.chunkIndex++
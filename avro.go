@@ -0,0 +1,552 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// AvroKind is an Avro schema type. / AvroKind — тип схемы Avro.
+type AvroKind uint8
+
+const (
+	AvroNull AvroKind = iota
+	AvroBoolean
+	AvroInt
+	AvroLong
+	AvroFloat
+	AvroDouble
+	AvroBytes
+	AvroString
+	AvroRecord
+	AvroEnum
+	AvroArray
+	AvroMap
+	AvroUnion
+	AvroFixed
+)
+
+var avroPrimitives = map[string]AvroKind{
+	"null":    AvroNull,
+	"boolean": AvroBoolean,
+	"int":     AvroInt,
+	"long":    AvroLong,
+	"float":   AvroFloat,
+	"double":  AvroDouble,
+	"bytes":   AvroBytes,
+	"string":  AvroString,
+}
+
+var (
+	// ErrAvroShortBuffer is returned when input ends mid-value. / ErrAvroShortBuffer — вход закончился посреди значения.
+	ErrAvroShortBuffer = errors.New("arena: avro: unexpected end of input")
+	// ErrAvroInvalidData is returned for malformed input. / ErrAvroInvalidData — некорректные входные данные.
+	ErrAvroInvalidData = errors.New("arena: avro: invalid data")
+)
+
+const (
+	avroMaxDepth = 64
+	// avroMaxZeroWidthItems caps arrays whose items take no input bytes. / Предел для массивов из элементов нулевой ширины.
+	avroMaxZeroWidthItems = 1 << 16
+)
+
+// AvroSchema is a parsed Avro schema. / AvroSchema — разобранная схема Avro.
+//
+// Schemas are long-lived and live on the heap; only decoded values go to the arena.
+type AvroSchema struct {
+	Kind     AvroKind
+	Name     string        // Full name of record, enum or fixed. / Полное имя record, enum или fixed.
+	Fields   []AvroField   // Record fields. / Поля record.
+	Items    *AvroSchema   // Array items or map values. / Элементы массива или значения map.
+	Branches []*AvroSchema // Union branches. / Ветви union.
+	Symbols  []string      // Enum symbols. / Символы enum.
+	Size     int           // Fixed size. / Размер fixed.
+}
+
+// AvroField is a record field. / AvroField — поле record.
+type AvroField struct {
+	Name string
+	Type *AvroSchema
+}
+
+// FieldIndex returns the index of a record field or -1. / FieldIndex возвращает индекс поля record или -1.
+func (s *AvroSchema) FieldIndex(name string) int {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseAvroSchema parses a JSON Avro schema. / ParseAvroSchema разбирает JSON-схему Avro.
+func ParseAvroSchema(schemaJSON []byte) (*AvroSchema, error) {
+	var raw any
+	if err := json.Unmarshal(schemaJSON, &raw); err != nil {
+		return nil, fmt.Errorf("arena: avro: %w", err)
+	}
+	p := avroSchemaParser{named: make(map[string]*AvroSchema)}
+	return p.parse(raw, "")
+}
+
+type avroSchemaParser struct {
+	named map[string]*AvroSchema
+}
+
+func (p *avroSchemaParser) parse(raw any, namespace string) (*AvroSchema, error) {
+	switch v := raw.(type) {
+	case string:
+		return p.lookup(v, namespace)
+	case []any:
+		s := &AvroSchema{Kind: AvroUnion, Branches: make([]*AvroSchema, len(v))}
+		for i, b := range v {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.Branches[i] = branch
+		}
+		return s, nil
+	case map[string]any:
+		return p.parseObject(v, namespace)
+	}
+	return nil, fmt.Errorf("arena: avro: unexpected schema element %T", raw)
+}
+
+func (p *avroSchemaParser) lookup(name, namespace string) (*AvroSchema, error) {
+	if k, ok := avroPrimitives[name]; ok {
+		return &AvroSchema{Kind: k}, nil
+	}
+	if s, ok := p.named[avroFullName(name, namespace)]; ok {
+		return s, nil
+	}
+	if s, ok := p.named[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("arena: avro: unknown type %q", name)
+}
+
+func (p *avroSchemaParser) parseObject(obj map[string]any, namespace string) (*AvroSchema, error) {
+	typ, ok := obj["type"].(string)
+	if !ok {
+		// {"type": {...}} or {"type": [...]} wraps another schema. / Обертка над другой схемой.
+		return p.parse(obj["type"], namespace)
+	}
+
+	switch typ {
+	case "record", "error", "enum", "fixed":
+		name, _ := obj["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("arena: avro: %s without name", typ)
+		}
+		if ns, ok := obj["namespace"].(string); ok {
+			namespace = ns
+		}
+		full := avroFullName(name, namespace)
+		if i := strings.LastIndexByte(full, '.'); i >= 0 {
+			namespace = full[:i]
+		}
+		s := &AvroSchema{Name: full}
+		p.named[full] = s
+
+		switch typ {
+		case "enum":
+			s.Kind = AvroEnum
+			syms, _ := obj["symbols"].([]any)
+			for _, sym := range syms {
+				str, ok := sym.(string)
+				if !ok {
+					return nil, fmt.Errorf("arena: avro: enum %s has non-string symbol", full)
+				}
+				s.Symbols = append(s.Symbols, str)
+			}
+		case "fixed":
+			s.Kind = AvroFixed
+			size, ok := obj["size"].(float64)
+			if !ok || size < 0 {
+				return nil, fmt.Errorf("arena: avro: fixed %s has invalid size", full)
+			}
+			s.Size = int(size)
+		default:
+			s.Kind = AvroRecord
+			fields, _ := obj["fields"].([]any)
+			for _, f := range fields {
+				fobj, ok := f.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("arena: avro: record %s has malformed field", full)
+				}
+				fname, _ := fobj["name"].(string)
+				ftype, err := p.parse(fobj["type"], namespace)
+				if err != nil {
+					return nil, err
+				}
+				s.Fields = append(s.Fields, AvroField{Name: fname, Type: ftype})
+			}
+		}
+		return s, nil
+
+	case "array", "map":
+		key := "items"
+		kind := AvroArray
+		if typ == "map" {
+			key = "values"
+			kind = AvroMap
+		}
+		items, err := p.parse(obj[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &AvroSchema{Kind: kind, Items: items}, nil
+	}
+
+	// Primitive written as an object, e.g. with a logicalType. / Примитив в виде объекта, например с logicalType.
+	return p.lookup(typ, namespace)
+}
+
+func avroFullName(name, namespace string) string {
+	if namespace == "" || strings.IndexByte(name, '.') >= 0 {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// AvroValue is a decoded Avro datum. / AvroValue — декодированное значение Avro.
+//
+// Which fields are set depends on Kind:
+//   - AvroBoolean: Long is 0 or 1.
+//   - AvroInt, AvroLong, AvroEnum (symbol index): Long.
+//   - AvroFloat, AvroDouble: Double.
+//   - AvroString: Str. AvroBytes, AvroFixed: Bytes.
+//   - AvroRecord: Items holds fields in schema order.
+//   - AvroArray: Items. AvroMap: Keys and Items in parallel.
+//
+// For a union schema the value has the Kind of the chosen branch and Branch
+// holds its index. Strings, bytes and slices point into the arena.
+type AvroValue struct {
+	Kind   AvroKind
+	Branch int
+	Long   int64
+	Double float64
+	Str    string
+	Bytes  []byte
+	Items  []AvroValue
+	Keys   []string
+}
+
+// Bool returns a boolean value. / Bool возвращает логическое значение.
+func (v *AvroValue) Bool() bool { return v.Long != 0 }
+
+// AvroDecoder reads Avro binary encoding into arena memory.
+// AvroDecoder читает бинарное кодирование Avro в память арены.
+//
+// Primitive Read* methods serve hand-written decoders that fill transient
+// structs directly; Decode walks a schema into an AvroValue tree.
+type AvroDecoder struct {
+	a   *Arena
+	buf []byte
+	pos int
+}
+
+// NewAvroDecoder creates a decoder over data. / NewAvroDecoder создает декодер поверх data.
+func NewAvroDecoder(a *Arena, data []byte) *AvroDecoder {
+	if a == nil {
+		panic("arena: NewAvroDecoder called with nil arena")
+	}
+	return &AvroDecoder{a: a, buf: data}
+}
+
+// Reset points the decoder at new input. / Reset переключает декодер на новый вход.
+func (d *AvroDecoder) Reset(data []byte) {
+	d.buf = data
+	d.pos = 0
+}
+
+// Remaining returns the number of unread bytes. / Remaining возвращает число непрочитанных байт.
+func (d *AvroDecoder) Remaining() int {
+	return len(d.buf) - d.pos
+}
+
+// ReadLong reads a zigzag varint long. / ReadLong читает long (zigzag varint).
+func (d *AvroDecoder) ReadLong() (int64, error) {
+	v, n := binary.Varint(d.buf[d.pos:])
+	if n == 0 {
+		return 0, ErrAvroShortBuffer
+	}
+	if n < 0 {
+		return 0, ErrAvroInvalidData
+	}
+	d.pos += n
+	return v, nil
+}
+
+// ReadInt reads a zigzag varint int. / ReadInt читает int (zigzag varint).
+func (d *AvroDecoder) ReadInt() (int32, error) {
+	v, err := d.ReadLong()
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, ErrAvroInvalidData
+	}
+	return int32(v), err
+}
+
+// ReadBoolean reads a boolean. / ReadBoolean читает boolean.
+func (d *AvroDecoder) ReadBoolean() (bool, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return false, err
+	}
+	return b[0] != 0, nil
+}
+
+// ReadFloat reads a float. / ReadFloat читает float.
+func (d *AvroDecoder) ReadFloat() (float32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+}
+
+// ReadDouble reads a double. / ReadDouble читает double.
+func (d *AvroDecoder) ReadDouble() (float64, error) {
+	b, err := d.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+}
+
+// ReadString reads a string into the arena. / ReadString читает строку в арену.
+func (d *AvroDecoder) ReadString() (string, error) {
+	b, err := d.readLengthPrefixed()
+	if err != nil {
+		return "", err
+	}
+	return d.a.AllocBytesToString(b), nil
+}
+
+// ReadBytes reads a bytes value into the arena. / ReadBytes читает bytes в арену.
+func (d *AvroDecoder) ReadBytes() ([]byte, error) {
+	b, err := d.readLengthPrefixed()
+	if err != nil {
+		return nil, err
+	}
+	return d.copyBytes(b), nil
+}
+
+// ReadFixed reads n raw bytes into the arena. / ReadFixed читает n байт в арену.
+func (d *AvroDecoder) ReadFixed(n int) ([]byte, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return d.copyBytes(b), nil
+}
+
+// ReadBlockCount reads an array/map block header; 0 means the end.
+// ReadBlockCount читает заголовок блока массива/map; 0 означает конец.
+func (d *AvroDecoder) ReadBlockCount() (int, error) {
+	n, err := d.ReadLong()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		// Negative count is followed by the block size in bytes. / За отрицательным счетчиком следует размер блока.
+		if _, err := d.ReadLong(); err != nil {
+			return 0, err
+		}
+		n = -n
+	}
+	if n < 0 || n > math.MaxInt32 {
+		return 0, ErrAvroInvalidData
+	}
+	return int(n), nil
+}
+
+// Decode reads one datum of the given schema. / Decode читает одно значение по схеме.
+func (d *AvroDecoder) Decode(schema *AvroSchema) (AvroValue, error) {
+	return d.decode(schema, 0)
+}
+
+func (d *AvroDecoder) decode(s *AvroSchema, depth int) (AvroValue, error) {
+	if depth > avroMaxDepth {
+		return AvroValue{}, ErrAvroInvalidData
+	}
+	v := AvroValue{Kind: s.Kind}
+	var err error
+	switch s.Kind {
+	case AvroNull:
+	case AvroBoolean:
+		var b bool
+		b, err = d.ReadBoolean()
+		if b {
+			v.Long = 1
+		}
+	case AvroInt:
+		var i int32
+		i, err = d.ReadInt()
+		v.Long = int64(i)
+	case AvroLong:
+		v.Long, err = d.ReadLong()
+	case AvroFloat:
+		var f float32
+		f, err = d.ReadFloat()
+		v.Double = float64(f)
+	case AvroDouble:
+		v.Double, err = d.ReadDouble()
+	case AvroString:
+		v.Str, err = d.ReadString()
+	case AvroBytes:
+		v.Bytes, err = d.ReadBytes()
+	case AvroFixed:
+		v.Bytes, err = d.ReadFixed(s.Size)
+	case AvroEnum:
+		var i int32
+		i, err = d.ReadInt()
+		if err == nil && (i < 0 || int(i) >= len(s.Symbols)) {
+			err = ErrAvroInvalidData
+		}
+		v.Long = int64(i)
+	case AvroRecord:
+		v.Items = MakeSlice[AvroValue](d.a, len(s.Fields), len(s.Fields))
+		for i := range s.Fields {
+			if v.Items[i], err = d.decode(s.Fields[i].Type, depth+1); err != nil {
+				break
+			}
+		}
+	case AvroArray, AvroMap:
+		err = d.decodeBlocks(s, &v, depth)
+	case AvroUnion:
+		var idx int64
+		if idx, err = d.ReadLong(); err != nil {
+			break
+		}
+		if idx < 0 || idx >= int64(len(s.Branches)) {
+			return AvroValue{}, ErrAvroInvalidData
+		}
+		if v, err = d.decode(s.Branches[idx], depth+1); err != nil {
+			break
+		}
+		v.Branch = int(idx)
+	default:
+		err = ErrAvroInvalidData
+	}
+	if err != nil {
+		return AvroValue{}, err
+	}
+	return v, nil
+}
+
+func (d *AvroDecoder) decodeBlocks(s *AvroSchema, v *AvroValue, depth int) error {
+	// Items that read no bytes cannot be bounded by the input length. / Элементы без байтов на входе нельзя ограничить длиной входа.
+	zeroWidth := s.Kind == AvroArray && avroZeroWidth(s.Items, depth)
+	total := 0
+	for {
+		n, err := d.ReadBlockCount()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if zeroWidth {
+			if total += n; total > avroMaxZeroWidthItems {
+				return ErrAvroInvalidData
+			}
+		} else if n > d.Remaining() {
+			return ErrAvroShortBuffer
+		}
+		// Reserve the whole block up front; append then stays within capacity. / Резервируем весь блок сразу.
+		if s.Kind == AvroMap {
+			v.Keys = avroReserve(d.a, v.Keys, n)
+		}
+		v.Items = avroReserve(d.a, v.Items, n)
+		for i := 0; i < n; i++ {
+			if s.Kind == AvroMap {
+				key, err := d.ReadString()
+				if err != nil {
+					return err
+				}
+				v.Keys = append(v.Keys, key)
+			}
+			item, err := d.decode(s.Items, depth+1)
+			if err != nil {
+				return err
+			}
+			v.Items = append(v.Items, item)
+		}
+	}
+}
+
+// avroReserve makes room for n more elements. Capacity at least doubles, so
+// many small blocks do not copy the slice once per block.
+// avroReserve освобождает место под n элементов; емкость как минимум удваивается.
+func avroReserve[T any](a *Arena, s []T, n int) []T {
+	if len(s)+n <= cap(s) {
+		return s
+	}
+	out := MakeSlice[T](a, len(s), max(len(s)+n, 2*cap(s)))
+	copy(out, s)
+	return out
+}
+
+// avroZeroWidth reports whether values of s are encoded in zero bytes.
+// avroZeroWidth сообщает, кодируются ли значения s нулем байт.
+func avroZeroWidth(s *AvroSchema, depth int) bool {
+	if depth > avroMaxDepth {
+		return false // Decoding fails on depth anyway. / Декодирование все равно упадет по глубине.
+	}
+	switch s.Kind {
+	case AvroNull:
+		return true
+	case AvroFixed:
+		return s.Size == 0
+	case AvroRecord:
+		for i := range s.Fields {
+			if !avroZeroWidth(s.Fields[i].Type, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (d *AvroDecoder) copyBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	out := d.a.AllocBytes(len(b))
+	copy(out, b)
+	return out
+}
+
+func (d *AvroDecoder) next(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, ErrAvroInvalidData
+	}
+	if n > len(d.buf)-d.pos {
+		return nil, ErrAvroShortBuffer
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *AvroDecoder) readLengthPrefixed() ([]byte, error) {
+	n, err := d.ReadLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > int64(d.Remaining()) {
+		if n > 0 {
+			return nil, ErrAvroShortBuffer
+		}
+		return nil, ErrAvroInvalidData
+	}
+	return d.next(int(n))
+}
@@ -0,0 +1,169 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+const avroUserSchema = `{
+	"type": "record", "name": "User", "namespace": "test",
+	"fields": [
+		{"name": "id", "type": "long"},
+		{"name": "name", "type": "string"},
+		{"name": "email", "type": ["null", "string"]},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attrs", "type": {"type": "map", "values": "int"}},
+		{"name": "role", "type": {"type": "enum", "name": "Role", "symbols": ["USER", "ADMIN"]}},
+		{"name": "score", "type": "double"},
+		{"name": "active", "type": "boolean"}
+	]
+}`
+
+var avroUserDatum = []byte{
+	0x54,                     // id: 42
+	0x08, 'j', 'o', 'h', 'n', // name
+	0x02, 0x06, 'a', '@', 'b', // email: branch 1, "a@b"
+	0x03, 0x0c, 0x04, 'g', 'o', 0x04, 'd', 'b', 0x00, // tags: block of -2 with size 6, then end
+	0x02, 0x02, 'k', 0x0e, 0x00, // attrs: {"k": 7}
+	0x02,                                           // role: ADMIN
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f, // score: 1.5
+	0x01, // active
+}
+
+func TestAvroDecodeRecord(t *testing.T) {
+	schema, err := ParseAvroSchema([]byte(avroUserSchema))
+	if err != nil {
+		t.Fatalf("ParseAvroSchema: %v", err)
+	}
+	a := NewArena(512, 0)
+	d := NewAvroDecoder(a, avroUserDatum)
+	v, err := d.Decode(schema)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if d.Remaining() != 0 {
+		t.Fatalf("expected input to be consumed, %d bytes left", d.Remaining())
+	}
+
+	f := v.Items
+	if v.Kind != AvroRecord || len(f) != len(schema.Fields) {
+		t.Fatalf("unexpected record: kind=%d fields=%d", v.Kind, len(f))
+	}
+	if f[0].Long != 42 || f[1].Str != "john" {
+		t.Fatalf("unexpected id/name: %d %q", f[0].Long, f[1].Str)
+	}
+	if f[2].Branch != 1 || f[2].Kind != AvroString || f[2].Str != "a@b" {
+		t.Fatalf("unexpected union: %+v", f[2])
+	}
+	if len(f[3].Items) != 2 || f[3].Items[0].Str != "go" || f[3].Items[1].Str != "db" {
+		t.Fatalf("unexpected tags: %+v", f[3].Items)
+	}
+	if len(f[4].Keys) != 1 || f[4].Keys[0] != "k" || f[4].Items[0].Long != 7 {
+		t.Fatalf("unexpected attrs: %+v", f[4])
+	}
+	role := schema.Fields[schema.FieldIndex("role")].Type
+	if role.Symbols[f[5].Long] != "ADMIN" {
+		t.Fatalf("unexpected role index %d", f[5].Long)
+	}
+	if f[6].Double != 1.5 || !f[7].Bool() {
+		t.Fatalf("unexpected score/active: %v %v", f[6].Double, f[7].Bool())
+	}
+}
+
+func TestAvroSchemaRecursiveReference(t *testing.T) {
+	schema, err := ParseAvroSchema([]byte(`{"type":"record","name":"Node","fields":[
+		{"name":"value","type":"int"},
+		{"name":"next","type":["null","Node"]}]}`))
+	if err != nil {
+		t.Fatalf("ParseAvroSchema: %v", err)
+	}
+	if schema.Fields[1].Type.Branches[1] != schema {
+		t.Fatal("expected named reference to resolve to the record itself")
+	}
+
+	// 1 -> 2 -> null
+	data := []byte{0x02, 0x02, 0x04, 0x00}
+	v, err := NewAvroDecoder(NewArena(256, 0), data).Decode(schema)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	next := v.Items[1]
+	if v.Items[0].Long != 1 || next.Items[0].Long != 2 || next.Items[1].Kind != AvroNull {
+		t.Fatalf("unexpected list: %+v", v)
+	}
+}
+
+func TestAvroDecodeTruncatedInput(t *testing.T) {
+	schema, err := ParseAvroSchema([]byte(avroUserSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewArena(512, 0)
+	for n := 0; n < len(avroUserDatum); n++ {
+		_, err := NewAvroDecoder(a, avroUserDatum[:n]).Decode(schema)
+		if !errors.Is(err, ErrAvroShortBuffer) && !errors.Is(err, ErrAvroInvalidData) {
+			t.Fatalf("prefix %d: expected decode error, got %v", n, err)
+		}
+	}
+}
+
+func TestParseAvroSchemaUnknownType(t *testing.T) {
+	if _, err := ParseAvroSchema([]byte(`{"type":"array","items":"Missing"}`)); err == nil {
+		t.Fatal("expected error for unknown named type")
+	}
+}
+
+func TestAvroDecodeZeroWidthItemsBounded(t *testing.T) {
+	for _, items := range []string{`"null"`, `{"type":"fixed","name":"Empty","size":0}`, `{"type":"record","name":"R","fields":[]}`} {
+		schema, err := ParseAvroSchema([]byte(`{"type":"array","items":` + items + `}`))
+		if err != nil {
+			t.Fatalf("ParseAvroSchema(%s): %v", items, err)
+		}
+
+		a := NewArena(4096, 0)
+		huge := append(binary.AppendVarint(nil, 5_000_000), 0x00)
+		if _, err := NewAvroDecoder(a, huge).Decode(schema); !errors.Is(err, ErrAvroInvalidData) {
+			t.Fatalf("items %s: expected ErrAvroInvalidData, got %v", items, err)
+		}
+		if a.UsedBytes() > 1<<24 {
+			t.Fatalf("items %s: arena grew to %d bytes on a %d-byte input", items, a.UsedBytes(), len(huge))
+		}
+
+		v, err := NewAvroDecoder(a, []byte{0x06, 0x00}).Decode(schema)
+		if err != nil || len(v.Items) != 3 {
+			t.Fatalf("items %s: expected 3 items, got %d (%v)", items, len(v.Items), err)
+		}
+	}
+}
+
+func TestAvroDecodeArenaUsageBoundedByInput(t *testing.T) {
+	schema, err := ParseAvroSchema([]byte(`{"type":"array","items":"long"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const items = 1 << 16
+	single := binary.AppendVarint(nil, items)
+	var small []byte
+	for i := 0; i < items; i++ {
+		single = append(single, 0x02)
+		small = append(small, 0x02, 0x02) // Block of one item. / Блок из одного элемента.
+	}
+	single = append(single, 0x00)
+	small = append(small, 0x00)
+
+	perByte := int(unsafe.Sizeof(AvroValue{}))
+	for name, data := range map[string][]byte{"single block": single, "one-item blocks": small} {
+		a := NewArena(1<<20, 0)
+		v, err := NewAvroDecoder(a, data).Decode(schema)
+		if err != nil || len(v.Items) != items {
+			t.Fatalf("%s: decoded %d items, err=%v", name, len(v.Items), err)
+		}
+		if limit := 2 * len(data) * perByte; a.UsedBytes() > limit {
+			t.Fatalf("%s: arena used %d bytes for %d input bytes, limit %d", name, a.UsedBytes(), len(data), limit)
+		}
+	}
+}
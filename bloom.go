@@ -0,0 +1,119 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// BloomFilter is a Bloom filter whose bit array lives in the arena.
+// BloomFilter — фильтр Блума, битовый массив которого лежит в арене.
+//
+// Hashes use a per-filter random seed, so filters are meant for transient,
+// in-process use and cannot be compared or merged across processes.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // Number of bits. / Число бит.
+	k    int    // Hashes per item. / Число хешей на элемент.
+	seed maphash.Seed
+}
+
+// NewBloomFilter sizes a filter for expectedItems at falsePositiveRate.
+// NewBloomFilter создает фильтр под expectedItems элементов с вероятностью ложного срабатывания falsePositiveRate.
+func NewBloomFilter(a *Arena, expectedItems int, falsePositiveRate float64) *BloomFilter {
+	if expectedItems <= 0 {
+		panic("arena: BloomFilter expected items must be positive")
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic("arena: BloomFilter false positive rate must be in (0, 1)")
+	}
+
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / n * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	words := (int(m) + 63) / 64
+
+	f := New[BloomFilter](a)
+	*f = BloomFilter{
+		bits: MakeSlice[uint64](a, words, words),
+		m:    uint64(words * 64),
+		k:    k,
+		seed: maphash.MakeSeed(),
+	}
+	// Arena memory may hold data from before Reset. / Память арены может содержать данные до Reset.
+	clear(f.bits)
+	return f
+}
+
+// Add inserts b. / Add добавляет b.
+func (f *BloomFilter) Add(b []byte) {
+	f.add(maphash.Bytes(f.seed, b))
+}
+
+// AddString inserts s. / AddString добавляет s.
+func (f *BloomFilter) AddString(s string) {
+	f.add(maphash.String(f.seed, s))
+}
+
+// AddBatch inserts every item. / AddBatch добавляет все элементы.
+func (f *BloomFilter) AddBatch(items [][]byte) {
+	for _, b := range items {
+		f.add(maphash.Bytes(f.seed, b))
+	}
+}
+
+// AddStrings inserts every string. / AddStrings добавляет все строки.
+func (f *BloomFilter) AddStrings(items []string) {
+	for _, s := range items {
+		f.add(maphash.String(f.seed, s))
+	}
+}
+
+// Test reports whether b may have been added; false is definitive.
+// Test сообщает, мог ли b быть добавлен; false — точный ответ.
+func (f *BloomFilter) Test(b []byte) bool {
+	return f.test(maphash.Bytes(f.seed, b))
+}
+
+// TestString reports whether s may have been added. / TestString сообщает, могла ли s быть добавлена.
+func (f *BloomFilter) TestString(s string) bool {
+	return f.test(maphash.String(f.seed, s))
+}
+
+// Clear removes all items. / Clear удаляет все элементы.
+func (f *BloomFilter) Clear() {
+	clear(f.bits)
+}
+
+// Bits returns the filter size in bits. / Bits возвращает размер фильтра в битах.
+func (f *BloomFilter) Bits() int { return int(f.m) }
+
+// Hashes returns the number of hash functions. / Hashes возвращает число хеш-функций.
+func (f *BloomFilter) Hashes() int { return f.k }
+
+// Double hashing (Kirsch–Mitzenmacher) derives k probes from one 64-bit hash.
+// Двойное хеширование (Kirsch–Mitzenmacher) дает k проб из одного 64-битного хеша.
+func (f *BloomFilter) add(h uint64) {
+	h2 := (h>>33 | h<<31) | 1
+	for i := 0; i < f.k; i++ {
+		idx := h % f.m
+		f.bits[idx>>6] |= 1 << (idx & 63)
+		h += h2
+	}
+}
+
+func (f *BloomFilter) test(h uint64) bool {
+	h2 := (h>>33 | h<<31) | 1
+	for i := 0; i < f.k; i++ {
+		idx := h % f.m
+		if f.bits[idx>>6]&(1<<(idx&63)) == 0 {
+			return false
+		}
+		h += h2
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestBloomFilterNoFalseNegatives(t *testing.T) {
+	a := NewArena(4096, 0)
+	f := NewBloomFilter(a, 1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.TestString(strconv.Itoa(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	f := NewBloomFilter(NewArena(4096, 0), 1000, 0.01)
+	batch := make([][]byte, 1000)
+	for i := range batch {
+		batch[i] = []byte(strconv.Itoa(i))
+	}
+	f.AddBatch(batch)
+
+	fp := 0
+	const probes = 10000
+	for i := 0; i < probes; i++ {
+		if f.Test([]byte("miss-" + strconv.Itoa(i))) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / probes; rate > 0.03 {
+		t.Fatalf("false positive rate too high: %.4f", rate)
+	}
+}
+
+func TestBloomFilterStartsEmptyOnDirtyArena(t *testing.T) {
+	a := NewArena(4096, 0)
+	dirty := a.AllocBytes(2048)
+	for i := range dirty {
+		dirty[i] = 0xff
+	}
+	a.Reset()
+
+	f := NewBloomFilter(a, 100, 0.01)
+	if f.TestString("anything") {
+		t.Fatal("new filter must be empty even on reused arena memory")
+	}
+	f.AddString("x")
+	f.Clear()
+	if f.TestString("x") {
+		t.Fatal("expected Clear to remove items")
+	}
+}
+
+func TestNewBloomFilterPanicsOnInvalidArgs(t *testing.T) {
+	a := NewArena(256, 0)
+	mustPanic(t, "zero items", func() {
+		_ = NewBloomFilter(a, 0, 0.01)
+	})
+	mustPanic(t, "rate out of range", func() {
+		_ = NewBloomFilter(a, 10, 1)
+	})
+}
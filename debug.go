@@ -0,0 +1,55 @@
+package arena
+
+import "sort"
+
+// OwnerAllocStats is the allocation volume attributed to one owner label.
+// OwnerAllocStats — объем аллокаций, приписанный одной метке владельца.
+type OwnerAllocStats struct {
+	Owner  string
+	Bytes  uint64
+	Allocs uint64
+}
+
+// DebugTracking reports whether the package was built with the arenadebug tag.
+// DebugTracking сообщает, собран ли пакет с тегом arenadebug.
+func DebugTracking() bool { return debugTracking }
+
+// SetOwnerLabel attributes subsequent allocations to label (e.g. a worker
+// name). It costs nothing unless built with -tags arenadebug.
+// SetOwnerLabel приписывает последующие аллокации метке label; без тега arenadebug ничего не стоит.
+func (a *Arena) SetOwnerLabel(label string) {
+	if debugTracking {
+		a.debug.setOwner(label)
+	}
+}
+
+// OwnerStats returns allocations since the last Reset grouped by owner label,
+// largest first. Allocations made before any SetOwnerLabel are under "".
+// Returns nil unless built with -tags arenadebug.
+// OwnerStats возвращает аллокации с последнего Reset по меткам владельцев (по убыванию); без arenadebug — nil.
+func (a *Arena) OwnerStats() []OwnerAllocStats {
+	if !debugTracking {
+		return nil
+	}
+	return a.debug.snapshot()
+}
+
+// OwnerStats returns per-owner totals of every arena returned with Put,
+// largest first. Returns nil unless built with -tags arenadebug.
+// OwnerStats возвращает суммарные аллокации по владельцам для всех арен, возвращенных через Put; без arenadebug — nil.
+func (p *ArenaPool) OwnerStats() []OwnerAllocStats {
+	if !debugTracking {
+		return nil
+	}
+	return p.debug.snapshot()
+}
+
+func sortOwnerStats(stats []OwnerAllocStats) []OwnerAllocStats {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Bytes != stats[j].Bytes {
+			return stats[i].Bytes > stats[j].Bytes
+		}
+		return stats[i].Owner < stats[j].Owner
+	})
+	return stats
+}
@@ -0,0 +1,19 @@
+//go:build !arenadebug
+
+package arena
+
+const debugTracking = false
+
+// debugState is empty without the arenadebug tag, so tracking costs nothing.
+// debugState пуст без тега arenadebug, поэтому учет ничего не стоит.
+type debugState struct{}
+
+func (d *debugState) setOwner(string)             {}
+func (d *debugState) record(int)                  {}
+func (d *debugState) reset()                      {}
+func (d *debugState) snapshot() []OwnerAllocStats { return nil }
+
+type poolDebugState struct{}
+
+func (p *poolDebugState) collect(*debugState)         {}
+func (p *poolDebugState) snapshot() []OwnerAllocStats { return nil }
@@ -0,0 +1,84 @@
+//go:build arenadebug
+
+package arena
+
+import "sync"
+
+const debugTracking = true
+
+// debugState tracks allocations per owner label for one arena. / debugState считает аллокации по меткам владельцев для одной арены.
+type debugState struct {
+	owner  string
+	cur    *OwnerAllocStats // Entry for owner, cached for the hot path. / Запись текущего владельца.
+	owners map[string]*OwnerAllocStats
+}
+
+func (d *debugState) setOwner(label string) {
+	d.owner = label
+	d.cur = nil
+}
+
+func (d *debugState) record(size int) {
+	if d.cur == nil {
+		if d.owners == nil {
+			d.owners = make(map[string]*OwnerAllocStats)
+		}
+		d.cur = d.owners[d.owner]
+		if d.cur == nil {
+			d.cur = &OwnerAllocStats{Owner: d.owner}
+			d.owners[d.owner] = d.cur
+		}
+	}
+	d.cur.Bytes += uint64(size)
+	d.cur.Allocs++
+}
+
+func (d *debugState) reset() {
+	clear(d.owners)
+	d.cur = nil
+}
+
+func (d *debugState) snapshot() []OwnerAllocStats {
+	out := make([]OwnerAllocStats, 0, len(d.owners))
+	for _, s := range d.owners {
+		out = append(out, *s)
+	}
+	return sortOwnerStats(out)
+}
+
+// poolDebugState aggregates owner stats of arenas returned to a pool. / poolDebugState суммирует статистику арен, возвращенных в пул.
+type poolDebugState struct {
+	mu     sync.Mutex
+	owners map[string]*OwnerAllocStats
+}
+
+// collect merges the arena's stats and clears its label so the next user
+// of the pooled arena starts unattributed.
+// collect сливает статистику арены и сбрасывает ее метку.
+func (p *poolDebugState) collect(d *debugState) {
+	p.mu.Lock()
+	if p.owners == nil {
+		p.owners = make(map[string]*OwnerAllocStats)
+	}
+	for owner, s := range d.owners {
+		t := p.owners[owner]
+		if t == nil {
+			t = &OwnerAllocStats{Owner: owner}
+			p.owners[owner] = t
+		}
+		t.Bytes += s.Bytes
+		t.Allocs += s.Allocs
+	}
+	p.mu.Unlock()
+	d.setOwner("")
+}
+
+func (p *poolDebugState) snapshot() []OwnerAllocStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]OwnerAllocStats, 0, len(p.owners))
+	for _, s := range p.owners {
+		out = append(out, *s)
+	}
+	return sortOwnerStats(out)
+}
@@ -0,0 +1,62 @@
+package arena
+
+import "testing"
+
+// Run with: go test -tags arenadebug -run OwnerStats
+func TestArenaOwnerStats(t *testing.T) {
+	a := NewArena(256, 0)
+	_ = a.AllocBytes(8)
+	a.SetOwnerLabel("worker-1")
+	_ = a.AllocString("hello")
+	_ = New[uint64](a)
+	a.SetOwnerLabel("worker-2")
+	_ = a.AllocBytes(100) // forces a new chunk; counted once
+
+	stats := a.OwnerStats()
+	if !DebugTracking() {
+		if stats != nil {
+			t.Fatalf("expected nil stats without arenadebug, got %v", stats)
+		}
+		return
+	}
+
+	want := []OwnerAllocStats{
+		{Owner: "worker-2", Bytes: 100, Allocs: 1},
+		{Owner: "worker-1", Bytes: 13, Allocs: 2},
+		{Owner: "", Bytes: 8, Allocs: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Fatalf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+
+	a.Reset()
+	if len(a.OwnerStats()) != 0 {
+		t.Fatal("expected Reset to clear owner stats")
+	}
+}
+
+func TestArenaPoolOwnerStats(t *testing.T) {
+	p := NewArenaPool(256, 0)
+	for _, owner := range []string{"a", "b", "a"} {
+		mem := p.Get()
+		mem.SetOwnerLabel(owner)
+		_ = mem.AllocBytes(10)
+		p.Put(mem)
+	}
+
+	stats := p.OwnerStats()
+	if !DebugTracking() {
+		if stats != nil {
+			t.Fatalf("expected nil stats without arenadebug, got %v", stats)
+		}
+		return
+	}
+	if len(stats) != 2 || stats[0] != (OwnerAllocStats{Owner: "a", Bytes: 20, Allocs: 2}) {
+		t.Fatalf("unexpected pool stats: %+v", stats)
+	}
+}
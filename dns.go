@@ -0,0 +1,239 @@
+package arena
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Common DNS record types. / Распространенные типы записей DNS.
+const (
+	DNSTypeA     uint16 = 1
+	DNSTypeNS    uint16 = 2
+	DNSTypeCNAME uint16 = 5
+	DNSTypeSOA   uint16 = 6
+	DNSTypePTR   uint16 = 12
+	DNSTypeMX    uint16 = 15
+	DNSTypeTXT   uint16 = 16
+	DNSTypeAAAA  uint16 = 28
+	DNSTypeSRV   uint16 = 33
+	DNSTypeOPT   uint16 = 41
+)
+
+var (
+	// ErrDNSShortBuffer is returned when the message is truncated. / ErrDNSShortBuffer — сообщение обрезано.
+	ErrDNSShortBuffer = errors.New("arena: dns: message too short")
+	// ErrDNSInvalidName is returned for malformed or looping names. / ErrDNSInvalidName — некорректное или зацикленное имя.
+	ErrDNSInvalidName = errors.New("arena: dns: invalid name")
+)
+
+const (
+	dnsHeaderLen   = 12
+	dnsMaxNameLen  = 255
+	dnsQuestionMin = 5  // root name + type + class
+	dnsResourceMin = 11 // root name + type + class + ttl + rdlength
+)
+
+// DNSHeader is the fixed message header. / DNSHeader — фиксированный заголовок сообщения.
+type DNSHeader struct {
+	ID    uint16
+	Flags uint16
+}
+
+// Response reports the QR bit. / Response возвращает бит QR.
+func (h DNSHeader) Response() bool { return h.Flags&0x8000 != 0 }
+
+// Opcode returns the OPCODE field. / Opcode возвращает поле OPCODE.
+func (h DNSHeader) Opcode() uint8 { return uint8(h.Flags>>11) & 0x0f }
+
+// RCode returns the RCODE field. / RCode возвращает поле RCODE.
+func (h DNSHeader) RCode() uint8 { return uint8(h.Flags) & 0x0f }
+
+// DNSQuestion is a question section entry. / DNSQuestion — запись секции вопросов.
+type DNSQuestion struct {
+	Name  string // Dotted form with trailing dot, e.g. "example.com.". / Имя с завершающей точкой.
+	Type  uint16
+	Class uint16
+}
+
+// DNSResource is a resource record. / DNSResource — ресурсная запись.
+type DNSResource struct {
+	Name  string
+	Type  uint16
+	Class uint16
+	TTL   uint32
+	Data  []byte // Raw RDATA copied into the arena. / Сырые RDATA, скопированные в арену.
+
+	// Target is the decompressed domain name for NS, CNAME, PTR, MX and SRV.
+	// Target — распакованное доменное имя для NS, CNAME, PTR, MX и SRV.
+	Target string
+}
+
+// DNSMessage is a parsed DNS message. / DNSMessage — разобранное DNS-сообщение.
+type DNSMessage struct {
+	Header      DNSHeader
+	Questions   []DNSQuestion
+	Answers     []DNSResource
+	Authorities []DNSResource
+	Additionals []DNSResource
+}
+
+// ParseDNSMessage parses a wire-format DNS message; the message, its sections,
+// names and RDATA are all allocated from the arena.
+// ParseDNSMessage разбирает DNS-сообщение; само сообщение, секции, имена и RDATA выделяются в арене.
+func ParseDNSMessage(a *Arena, msg []byte) (*DNSMessage, error) {
+	if len(msg) < dnsHeaderLen {
+		return nil, ErrDNSShortBuffer
+	}
+	qd := int(binary.BigEndian.Uint16(msg[4:]))
+	an := int(binary.BigEndian.Uint16(msg[6:]))
+	ns := int(binary.BigEndian.Uint16(msg[8:]))
+	ar := int(binary.BigEndian.Uint16(msg[10:]))
+	body := len(msg) - dnsHeaderLen
+	if qd*dnsQuestionMin+(an+ns+ar)*dnsResourceMin > body {
+		return nil, ErrDNSShortBuffer
+	}
+
+	m := New[DNSMessage](a)
+	*m = DNSMessage{
+		Header: DNSHeader{
+			ID:    binary.BigEndian.Uint16(msg[0:]),
+			Flags: binary.BigEndian.Uint16(msg[2:]),
+		},
+		Questions: MakeSlice[DNSQuestion](a, qd, qd),
+	}
+
+	off := dnsHeaderLen
+	var err error
+	for i := range m.Questions {
+		q := &m.Questions[i]
+		if q.Name, off, err = ParseDNSName(a, msg, off); err != nil {
+			return nil, err
+		}
+		if off+4 > len(msg) {
+			return nil, ErrDNSShortBuffer
+		}
+		q.Type = binary.BigEndian.Uint16(msg[off:])
+		q.Class = binary.BigEndian.Uint16(msg[off+2:])
+		off += 4
+	}
+	if m.Answers, off, err = parseDNSResources(a, msg, off, an); err != nil {
+		return nil, err
+	}
+	if m.Authorities, off, err = parseDNSResources(a, msg, off, ns); err != nil {
+		return nil, err
+	}
+	if m.Additionals, _, err = parseDNSResources(a, msg, off, ar); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func parseDNSResources(a *Arena, msg []byte, off int, n int) ([]DNSResource, int, error) {
+	rrs := MakeSlice[DNSResource](a, n, n)
+	var err error
+	for i := range rrs {
+		r := &rrs[i]
+		*r = DNSResource{}
+		if r.Name, off, err = ParseDNSName(a, msg, off); err != nil {
+			return nil, 0, err
+		}
+		if off+10 > len(msg) {
+			return nil, 0, ErrDNSShortBuffer
+		}
+		r.Type = binary.BigEndian.Uint16(msg[off:])
+		r.Class = binary.BigEndian.Uint16(msg[off+2:])
+		r.TTL = binary.BigEndian.Uint32(msg[off+4:])
+		rdlen := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+rdlen > len(msg) {
+			return nil, 0, ErrDNSShortBuffer
+		}
+		if rdlen > 0 {
+			r.Data = a.AllocBytes(rdlen)
+			copy(r.Data, msg[off:off+rdlen])
+		}
+
+		// Names inside RDATA may point anywhere in msg, so decode them now. / Имена в RDATA могут ссылаться на любую часть msg.
+		nameOff := -1
+		switch r.Type {
+		case DNSTypeNS, DNSTypeCNAME, DNSTypePTR:
+			nameOff = off
+		case DNSTypeMX:
+			nameOff = off + 2
+		case DNSTypeSRV:
+			nameOff = off + 6
+		}
+		if nameOff >= 0 {
+			// The name may point elsewhere but must not run past RDATA. / Имя может ссылаться наружу, но не выходить за RDATA.
+			if nameOff >= off+rdlen {
+				return nil, 0, ErrDNSShortBuffer
+			}
+			var end int
+			if r.Target, end, err = ParseDNSName(a, msg, nameOff); err != nil {
+				return nil, 0, err
+			}
+			if end > off+rdlen {
+				return nil, 0, ErrDNSInvalidName
+			}
+		}
+		off += rdlen
+	}
+	return rrs, off, nil
+}
+
+// ParseDNSName decodes a possibly compressed name at off and returns it with
+// the offset just past the name in place.
+// ParseDNSName декодирует (возможно сжатое) имя по смещению off и возвращает его и смещение за именем.
+func ParseDNSName(a *Arena, msg []byte, off int) (string, int, error) {
+	var buf [dnsMaxNameLen + 1]byte
+	n := 0
+	next := -1 // Offset after the name in place, set at the first pointer. / Смещение после имени, фиксируется на первом указателе.
+	limit := off
+
+	for {
+		if off >= len(msg) {
+			return "", 0, ErrDNSShortBuffer
+		}
+		c := int(msg[off])
+		off++
+		switch c & 0xc0 {
+		case 0x00:
+			if c == 0 {
+				if next < 0 {
+					next = off
+				}
+				if n == 0 {
+					return ".", next, nil
+				}
+				return a.AllocBytesToString(buf[:n]), next, nil
+			}
+			if off+c > len(msg) {
+				return "", 0, ErrDNSShortBuffer
+			}
+			if n+c+1 > dnsMaxNameLen {
+				return "", 0, ErrDNSInvalidName
+			}
+			n += copy(buf[n:], msg[off:off+c])
+			buf[n] = '.'
+			n++
+			off += c
+		case 0xc0:
+			if off >= len(msg) {
+				return "", 0, ErrDNSShortBuffer
+			}
+			ptr := (c&0x3f)<<8 | int(msg[off])
+			off++
+			if next < 0 {
+				next = off
+			}
+			// Pointers must go strictly backwards, which rules out loops. / Указатели должны идти строго назад — это исключает циклы.
+			if ptr >= limit {
+				return "", 0, ErrDNSInvalidName
+			}
+			limit = ptr
+			off = ptr
+		default:
+			return "", 0, ErrDNSInvalidName
+		}
+	}
+}
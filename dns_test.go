@@ -0,0 +1,95 @@
+package arena
+
+import (
+	"errors"
+	"testing"
+)
+
+// dnsSampleResponse answers "www.example.com. A" with a CNAME to
+// "example.com." (compressed) followed by an A record.
+var dnsSampleResponse = []byte{
+	0x12, 0x34, 0x81, 0x80, // id, flags: response, RD, RA
+	0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00,
+	// question @12
+	3, 'w', 'w', 'w', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0,
+	0x00, 0x01, 0x00, 0x01,
+	// answer 1: www.example.com. CNAME example.com.
+	0xc0, 12, 0x00, 0x05, 0x00, 0x01, 0x00, 0x00, 0x0e, 0x10, 0x00, 0x02, 0xc0, 16,
+	// answer 2: example.com. A 93.184.216.34
+	0xc0, 16, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x0e, 0x10, 0x00, 0x04, 93, 184, 216, 34,
+}
+
+func TestParseDNSMessage(t *testing.T) {
+	a := NewArena(512, 0)
+	m, err := ParseDNSMessage(a, dnsSampleResponse)
+	if err != nil {
+		t.Fatalf("ParseDNSMessage: %v", err)
+	}
+	if m.Header.ID != 0x1234 || !m.Header.Response() || m.Header.RCode() != 0 {
+		t.Fatalf("unexpected header: %+v", m.Header)
+	}
+	if len(m.Questions) != 1 || m.Questions[0].Name != "www.example.com." || m.Questions[0].Type != DNSTypeA {
+		t.Fatalf("unexpected questions: %+v", m.Questions)
+	}
+	if len(m.Answers) != 2 {
+		t.Fatalf("unexpected answer count: %d", len(m.Answers))
+	}
+	cname, rr := m.Answers[0], m.Answers[1]
+	if cname.Type != DNSTypeCNAME || cname.Name != "www.example.com." || cname.Target != "example.com." {
+		t.Fatalf("unexpected CNAME: %+v", cname)
+	}
+	if rr.Name != "example.com." || rr.TTL != 3600 || string(rr.Data) != "\x5d\xb8\xd8\x22" || rr.Target != "" {
+		t.Fatalf("unexpected A record: %+v", rr)
+	}
+	if m.Authorities != nil || m.Additionals != nil {
+		t.Fatal("expected empty authority and additional sections")
+	}
+}
+
+func TestParseDNSMessageTruncated(t *testing.T) {
+	a := NewArena(512, 0)
+	for n := 0; n < len(dnsSampleResponse); n++ {
+		if _, err := ParseDNSMessage(a, dnsSampleResponse[:n]); err == nil {
+			t.Fatalf("prefix %d: expected error", n)
+		}
+	}
+}
+
+func TestParseDNSNameRejectsPointerLoop(t *testing.T) {
+	msg := []byte{0xc0, 0x00}
+	if _, _, err := ParseDNSName(NewArena(64, 0), msg, 0); !errors.Is(err, ErrDNSInvalidName) {
+		t.Fatalf("expected ErrDNSInvalidName, got %v", err)
+	}
+}
+
+func TestParseDNSNameRoot(t *testing.T) {
+	name, next, err := ParseDNSName(NewArena(64, 0), []byte{0}, 0)
+	if err != nil || name != "." || next != 1 {
+		t.Fatalf("unexpected root name: %q %d %v", name, next, err)
+	}
+}
+
+func TestParseDNSMessageRejectsNameBeyondRDATA(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		typ   byte
+		rdata []byte
+	}{
+		// RDLENGTH covers only part of "foo."; the rest spills into trailing bytes.
+		{"cname", byte(DNSTypeCNAME), []byte{0x00, 0x02, 3, 'f', 'o', 'o', 0}},
+		// RDLENGTH ends right after the MX preference.
+		{"mx", byte(DNSTypeMX), []byte{0x00, 0x02, 0x00, 0x0a, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := []byte{
+				0x00, 0x01, 0x81, 0x80,
+				0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+				0, 0x00, tc.typ, 0x00, 0x01, 0x00, 0x00, 0x0e, 0x10,
+			}
+			msg = append(msg, tc.rdata...)
+			if _, err := ParseDNSMessage(NewArena(256, 0), msg); err == nil {
+				t.Fatal("expected error for name past RDATA")
+			}
+		})
+	}
+}
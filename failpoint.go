@@ -0,0 +1,42 @@
+package arena
+
+import "errors"
+
+// ErrInjectedFailure is the panic value raised by a triggered Failpoint.
+// ErrInjectedFailure — значение паники при срабатывании Failpoint.
+var ErrInjectedFailure = errors.New("arena: injected allocation failure")
+
+// Failpoint makes allocations fail deterministically so error paths can be
+// tested. Only available in builds with -tags arenafailpoint.
+// Failpoint детерминированно ломает аллокации для тестирования путей ошибок; только с -tags arenafailpoint.
+//
+// A triggered failpoint panics with ErrInjectedFailure before the arena is
+// modified, so the arena stays usable after recover. Any non-zero trigger
+// may fire; counters start when the failpoint is set. Allocations have no
+// error-returning variants, so failures surface only as this panic.
+// Аллокации не возвращают ошибок, поэтому сбой проявляется только паникой.
+type Failpoint struct {
+	// ChunksOnly limits the failpoint to chunk growth instead of every allocation.
+	// ChunksOnly ограничивает срабатывание ростом чанков.
+	ChunksOnly bool
+	// EveryN fails every Nth checked allocation. / EveryN ломает каждую N-ю аллокацию.
+	EveryN uint64
+	// AfterBytes fails once more than this many bytes were requested. / AfterBytes ломает аллокации после этого объема.
+	AfterBytes uint64
+	// Func fails the allocation when it returns true. / Func ломает аллокацию, если возвращает true.
+	Func func(size int) bool
+}
+
+// FailpointsEnabled reports whether the package was built with -tags arenafailpoint.
+// FailpointsEnabled сообщает, собран ли пакет с -tags arenafailpoint.
+func FailpointsEnabled() bool { return failpointsEnabled }
+
+// SetFailpoint installs fp on the arena; nil removes it. Panics unless built
+// with -tags arenafailpoint, so tests cannot silently run without it.
+// SetFailpoint устанавливает fp; nil снимает его. Без -tags arenafailpoint паникует.
+func (a *Arena) SetFailpoint(fp *Failpoint) {
+	if !failpointsEnabled {
+		panic("arena: failpoints require -tags arenafailpoint")
+	}
+	a.fail.set(fp)
+}
@@ -0,0 +1,11 @@
+//go:build !arenafailpoint
+
+package arena
+
+const failpointsEnabled = false
+
+// failState is empty without the arenafailpoint tag. / failState пуст без тега arenafailpoint.
+type failState struct{}
+
+func (f *failState) set(*Failpoint)  {}
+func (f *failState) check(int, bool) {}
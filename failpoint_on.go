@@ -0,0 +1,35 @@
+//go:build arenafailpoint
+
+package arena
+
+const failpointsEnabled = true
+
+// failState holds the active failpoint and its counters. / failState хранит активный failpoint и счетчики.
+type failState struct {
+	fp     *Failpoint
+	allocs uint64
+	bytes  uint64
+}
+
+func (f *failState) set(fp *Failpoint) {
+	if fp != nil {
+		cp := *fp
+		fp = &cp
+	}
+	*f = failState{fp: fp}
+}
+
+// check panics with ErrInjectedFailure when the failpoint fires. / check паникует, если failpoint срабатывает.
+func (f *failState) check(size int, chunk bool) {
+	fp := f.fp
+	if fp == nil || fp.ChunksOnly != chunk {
+		return
+	}
+	f.allocs++
+	f.bytes += uint64(size)
+	if (fp.EveryN > 0 && f.allocs%fp.EveryN == 0) ||
+		(fp.AfterBytes > 0 && f.bytes > fp.AfterBytes) ||
+		(fp.Func != nil && fp.Func(size)) {
+		panic(ErrInjectedFailure)
+	}
+}
@@ -0,0 +1,98 @@
+package arena
+
+import (
+	"errors"
+	"testing"
+)
+
+func expectInjectedFailure(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		err, _ := r.(error)
+		if !errors.Is(err, ErrInjectedFailure) {
+			t.Fatalf("%s: expected ErrInjectedFailure panic, got %v", name, r)
+		}
+	}()
+	fn()
+}
+
+// Run with: go test -tags arenafailpoint -run Failpoint
+func TestFailpointEveryN(t *testing.T) {
+	if !FailpointsEnabled() {
+		t.Skip("requires -tags arenafailpoint")
+	}
+	a := NewArena(256, 0)
+	a.SetFailpoint(&Failpoint{EveryN: 3})
+	_ = New[int](a)
+	_ = New[int](a)
+	used := a.UsedBytes()
+	expectInjectedFailure(t, "third allocation", func() {
+		_ = New[int](a)
+	})
+	if a.UsedBytes() != used {
+		t.Fatalf("failed allocation must not move the cursor: %d -> %d", used, a.UsedBytes())
+	}
+	_ = New[int](a) // counter continues: 4th succeeds
+
+	a.SetFailpoint(nil)
+	for i := 0; i < 10; i++ {
+		_ = New[int](a)
+	}
+
+	// An allocation that would grow the arena must fail before the new chunk is added.
+	b := NewArena(64, 0)
+	b.SetFailpoint(&Failpoint{EveryN: 2})
+	_ = b.AllocBytes(60)
+	expectInjectedFailure(t, "allocation forcing growth", func() {
+		_ = b.AllocBytes(60)
+	})
+	if b.UsedBytes() != 60 || len(b.chunks) != 1 {
+		t.Fatalf("failed growth must not touch the arena: used=%d chunks=%d", b.UsedBytes(), len(b.chunks))
+	}
+	_ = b.AllocBytes(60)
+	if b.UsedBytes() != 124 {
+		t.Fatalf("unexpected usage after recovery: %d", b.UsedBytes())
+	}
+}
+
+func TestFailpointAfterBytesAndFunc(t *testing.T) {
+	if !FailpointsEnabled() {
+		t.Skip("requires -tags arenafailpoint")
+	}
+	a := NewArena(256, 0)
+	a.SetFailpoint(&Failpoint{AfterBytes: 10})
+	_ = a.AllocBytes(10)
+	expectInjectedFailure(t, "over byte limit", func() {
+		_ = a.AllocString("x")
+	})
+
+	a.SetFailpoint(&Failpoint{Func: func(size int) bool { return size == 7 }})
+	_ = a.AllocBytes(6)
+	expectInjectedFailure(t, "callback", func() {
+		_ = a.AllocBytes(7)
+	})
+}
+
+func TestFailpointChunksOnly(t *testing.T) {
+	if !FailpointsEnabled() {
+		t.Skip("requires -tags arenafailpoint")
+	}
+	a := NewArena(64, 0)
+	a.SetFailpoint(&Failpoint{ChunksOnly: true, EveryN: 1})
+	_ = a.AllocBytes(60) // fits in the first chunk
+	expectInjectedFailure(t, "chunk growth", func() {
+		_ = a.AllocBytes(60)
+	})
+	a.Reset()
+	_ = a.AllocBytes(60) // existing chunk, no growth
+}
+
+func TestSetFailpointRequiresTag(t *testing.T) {
+	if FailpointsEnabled() {
+		t.Skip("only meaningful without arenafailpoint")
+	}
+	mustPanic(t, "SetFailpoint without tag", func() {
+		NewArena(64, 0).SetFailpoint(&Failpoint{EveryN: 1})
+	})
+}
@@ -0,0 +1,153 @@
+package arena
+
+// GraphBuilder collects directed edges and produces a CSR Graph; all arrays
+// are allocated from the arena.
+// GraphBuilder собирает ориентированные ребра и строит граф в формате CSR; все массивы выделяются в арене.
+type GraphBuilder struct {
+	a        *Arena
+	from     []uint32
+	to       []uint32
+	vertices int
+}
+
+// NewGraphBuilder creates a builder; vertices is the initial vertex count and
+// grows automatically with AddEdge. edgeHint presizes the edge buffers.
+// NewGraphBuilder создает билдер; vertices — начальное число вершин, edgeHint — ожидаемое число ребер.
+func NewGraphBuilder(a *Arena, vertices int, edgeHint int) *GraphBuilder {
+	if a == nil {
+		panic("arena: NewGraphBuilder called with nil arena")
+	}
+	if vertices < 0 || edgeHint < 0 {
+		panic("arena: GraphBuilder sizes must be non-negative")
+	}
+	return &GraphBuilder{
+		a:        a,
+		from:     MakeSlice[uint32](a, 0, edgeHint),
+		to:       MakeSlice[uint32](a, 0, edgeHint),
+		vertices: vertices,
+	}
+}
+
+// AddEdge adds a directed edge from -> to. / AddEdge добавляет ребро from -> to.
+func (b *GraphBuilder) AddEdge(from, to uint32) {
+	b.from = Append(b.a, b.from, from)
+	b.to = Append(b.a, b.to, to)
+	if n := int(max(from, to)) + 1; n > b.vertices {
+		b.vertices = n
+	}
+}
+
+// NumEdges returns the number of edges added so far. / NumEdges возвращает число добавленных ребер.
+func (b *GraphBuilder) NumEdges() int { return len(b.from) }
+
+// Finalize builds the CSR graph. Neighbors keep insertion order per vertex.
+// The builder stays usable; later edges only affect later Finalize calls.
+// Finalize строит граф CSR; порядок соседей совпадает с порядком добавления.
+func (b *GraphBuilder) Finalize() *Graph {
+	n := b.vertices
+	offsets := MakeSlice[uint32](b.a, n+1, n+1)
+	clear(offsets)
+	for _, f := range b.from {
+		offsets[f+1]++
+	}
+	for i := 1; i <= n; i++ {
+		offsets[i] += offsets[i-1]
+	}
+
+	edges := MakeSlice[uint32](b.a, len(b.to), len(b.to))
+	cursor := MakeSlice[uint32](b.a, n, n)
+	copy(cursor, offsets[:n])
+	for i, f := range b.from {
+		edges[cursor[f]] = b.to[i]
+		cursor[f]++
+	}
+
+	g := New[Graph](b.a)
+	*g = Graph{Offsets: offsets, Edges: edges}
+	return g
+}
+
+// Graph is a directed graph in compressed sparse row form.
+// Graph — ориентированный граф в формате CSR (compressed sparse row).
+//
+// The neighbors of v are Edges[Offsets[v]:Offsets[v+1]].
+type Graph struct {
+	Offsets []uint32
+	Edges   []uint32
+}
+
+// NumVertices returns the vertex count. / NumVertices возвращает число вершин.
+func (g *Graph) NumVertices() int { return len(g.Offsets) - 1 }
+
+// NumEdges returns the edge count. / NumEdges возвращает число ребер.
+func (g *Graph) NumEdges() int { return len(g.Edges) }
+
+// Neighbors returns the out-neighbors of v. / Neighbors возвращает исходящих соседей v.
+func (g *Graph) Neighbors(v uint32) []uint32 {
+	return g.Edges[g.Offsets[v]:g.Offsets[v+1]]
+}
+
+// NewVisitedSet allocates a visited set sized for g. / NewVisitedSet выделяет множество посещенных вершин под g.
+func (g *Graph) NewVisitedSet(a *Arena) *SparseSet {
+	return NewSparseSet(a, g.NumVertices())
+}
+
+// BFS visits vertices reachable from start in breadth-first order with their
+// depth; visit returns false to stop. Queue and visited set come from a.
+// A start outside the graph visits nothing.
+// BFS обходит вершины в ширину, передавая глубину; буферы берутся из a. Вершина вне графа ничего не обходит.
+func (g *Graph) BFS(a *Arena, start uint32, visit func(v uint32, depth int) bool) {
+	if int(start) >= g.NumVertices() {
+		return
+	}
+	visited := g.NewVisitedSet(a)
+	queue := MakeSlice[uint32](a, 0, g.NumVertices())
+	visited.Add(start)
+	queue = append(queue, start)
+
+	for head, depth := 0, 0; head < len(queue); depth++ {
+		levelEnd := len(queue)
+		for ; head < levelEnd; head++ {
+			v := queue[head]
+			if !visit(v, depth) {
+				return
+			}
+			for _, w := range g.Neighbors(v) {
+				if visited.Add(w) {
+					queue = append(queue, w) // Each vertex is queued once, so cap suffices. / Каждая вершина в очереди один раз.
+				}
+			}
+		}
+	}
+}
+
+// DFS visits vertices reachable from start in depth-first preorder; visit
+// returns false to stop. Stack and visited set come from a. A start outside
+// the graph visits nothing.
+// DFS обходит вершины в глубину (preorder); буферы берутся из a. Вершина вне графа ничего не обходит.
+func (g *Graph) DFS(a *Arena, start uint32, visit func(v uint32) bool) {
+	if int(start) >= g.NumVertices() {
+		return
+	}
+	visited := g.NewVisitedSet(a)
+	stack := MakeSlice[uint32](a, 0, 16)
+	stack = append(stack, start)
+
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visited.Add(v) {
+			continue
+		}
+		if !visit(v) {
+			return
+		}
+		nb := g.Neighbors(v)
+		// Push in reverse so the first neighbor is explored first. / Кладем в обратном порядке.
+		for i := len(nb) - 1; i >= 0; i-- {
+			if !visited.Contains(nb[i]) {
+				stack = Append(a, stack, nb[i])
+			}
+		}
+	}
+}
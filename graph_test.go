@@ -0,0 +1,98 @@
+package arena
+
+import (
+	"slices"
+	"testing"
+)
+
+func buildTestGraph(a *Arena) *Graph {
+	// 0 -> 1, 0 -> 2, 1 -> 3, 2 -> 3, 3 -> 4, 5 isolated via vertex hint.
+	b := NewGraphBuilder(a, 6, 0)
+	b.AddEdge(0, 1)
+	b.AddEdge(2, 3)
+	b.AddEdge(0, 2)
+	b.AddEdge(1, 3)
+	b.AddEdge(3, 4)
+	return b.Finalize()
+}
+
+func TestGraphBuilderCSR(t *testing.T) {
+	g := buildTestGraph(NewArena(1024, 0))
+	if g.NumVertices() != 6 || g.NumEdges() != 5 {
+		t.Fatalf("unexpected size: V=%d E=%d", g.NumVertices(), g.NumEdges())
+	}
+	if nb := g.Neighbors(0); !slices.Equal(nb, []uint32{1, 2}) {
+		t.Fatalf("unexpected neighbors of 0: %v", nb)
+	}
+	if nb := g.Neighbors(5); len(nb) != 0 {
+		t.Fatalf("expected no neighbors for 5, got %v", nb)
+	}
+}
+
+func TestGraphBuilderGrowsVertices(t *testing.T) {
+	b := NewGraphBuilder(NewArena(256, 0), 0, 0)
+	b.AddEdge(7, 2)
+	g := b.Finalize()
+	if g.NumVertices() != 8 || !slices.Equal(g.Neighbors(7), []uint32{2}) {
+		t.Fatalf("unexpected graph: V=%d", g.NumVertices())
+	}
+}
+
+func TestGraphBFSAndDFS(t *testing.T) {
+	a := NewArena(1024, 0)
+	g := buildTestGraph(a)
+
+	var order []uint32
+	var depths []int
+	g.BFS(a, 0, func(v uint32, depth int) bool {
+		order = append(order, v)
+		depths = append(depths, depth)
+		return true
+	})
+	if !slices.Equal(order, []uint32{0, 1, 2, 3, 4}) || !slices.Equal(depths, []int{0, 1, 1, 2, 3}) {
+		t.Fatalf("unexpected BFS: %v %v", order, depths)
+	}
+
+	order = order[:0]
+	g.DFS(a, 0, func(v uint32) bool {
+		order = append(order, v)
+		return true
+	})
+	if !slices.Equal(order, []uint32{0, 1, 3, 4, 2}) {
+		t.Fatalf("unexpected DFS: %v", order)
+	}
+
+	order = order[:0]
+	g.BFS(a, 0, func(v uint32, _ int) bool {
+		order = append(order, v)
+		return len(order) < 2
+	})
+	if len(order) != 2 {
+		t.Fatalf("expected BFS to stop early, visited %v", order)
+	}
+}
+
+func TestGraphTraversalStartOutOfRange(t *testing.T) {
+	a := NewArena(1024, 0)
+	empty := NewGraphBuilder(a, 0, 0).Finalize()
+	b := NewGraphBuilder(a, 0, 1)
+	b.AddEdge(0, 1)
+	small := b.Finalize()
+
+	for _, tc := range []struct {
+		g     *Graph
+		start uint32
+	}{{empty, 0}, {small, 2}, {small, 1 << 31}} {
+		tc.g.BFS(a, tc.start, func(v uint32, _ int) bool {
+			t.Fatalf("BFS from %d visited %d", tc.start, v)
+			return true
+		})
+		tc.g.DFS(a, tc.start, func(v uint32) bool {
+			t.Fatalf("DFS from %d visited %d", tc.start, v)
+			return true
+		})
+	}
+	mustPanic(t, "nil arena", func() {
+		NewGraphBuilder(nil, 0, 0)
+	})
+}
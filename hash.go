@@ -0,0 +1,50 @@
+package arena
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"unsafe"
+)
+
+// SumInto writes the current digest of h into arena memory. / SumInto записывает текущий дайджест h в память арены.
+//
+// Works with any hash.Hash, including hmac.New; h is not reset.
+func SumInto(a *Arena, h hash.Hash) []byte {
+	n := h.Size()
+	if n == 0 {
+		return nil
+	}
+	buf := a.AllocBytes(n)
+	return h.Sum(buf[:0])
+}
+
+// HexString hex-encodes b into an arena string. / HexString кодирует b в hex-строку в арене.
+func HexString(a *Arena, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	dst := a.AllocBytes(hex.EncodedLen(len(b)))
+	hex.Encode(dst, b)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// Base64String encodes b with enc into an arena string. / Base64String кодирует b через enc в строку в арене.
+func Base64String(a *Arena, enc *base64.Encoding, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	dst := a.AllocBytes(enc.EncodedLen(len(b)))
+	enc.Encode(dst, b)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// SumHex returns the hex digest of h as an arena string. / SumHex возвращает hex-дайджест h строкой в арене.
+func SumHex(a *Arena, h hash.Hash) string {
+	return HexString(a, SumInto(a, h))
+}
+
+// SumBase64 returns the digest of h encoded with enc as an arena string. / SumBase64 возвращает дайджест h в кодировке enc строкой в арене.
+func SumBase64(a *Arena, h hash.Hash, enc *base64.Encoding) string {
+	return Base64String(a, enc, SumInto(a, h))
+}
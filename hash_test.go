@@ -0,0 +1,50 @@
+package arena
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSumIntoMatchesHashSum(t *testing.T) {
+	a := NewArena(256, 0)
+	h := hmac.New(sha256.New, []byte("key"))
+	h.Write([]byte("payload"))
+
+	want := h.Sum(nil)
+	got := SumInto(a, h)
+	if !hmac.Equal(got, want) {
+		t.Fatalf("digest mismatch: got %x, want %x", got, want)
+	}
+	if a.UsedBytes() < len(want) {
+		t.Fatalf("expected digest in arena, used %d bytes", a.UsedBytes())
+	}
+}
+
+func TestSumHexAndBase64(t *testing.T) {
+	a := NewArena(256, 0)
+	h := sha256.New()
+	h.Write([]byte("abc"))
+	sum := h.Sum(nil)
+
+	if got, want := SumHex(a, h), hex.EncodeToString(sum); got != want {
+		t.Fatalf("unexpected hex: got %s, want %s", got, want)
+	}
+	if got, want := SumBase64(a, h, base64.RawURLEncoding), base64.RawURLEncoding.EncodeToString(sum); got != want {
+		t.Fatalf("unexpected base64: got %s, want %s", got, want)
+	}
+}
+
+func TestSumIntoDoesNotAllocate(t *testing.T) {
+	a := NewArena(64*1024, 0)
+	h := sha256.New()
+	h.Write([]byte("abc"))
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = SumHex(a, h)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected zero heap allocations, got %.1f", allocs)
+	}
+}
@@ -0,0 +1,84 @@
+package arena
+
+import (
+	"image"
+	"unsafe"
+)
+
+// ImageRowAlign is a row alignment that keeps every row on its own cache line.
+// ImageRowAlign — выравнивание строк по кэш-линии.
+const ImageRowAlign = 64
+
+// NewRGBA allocates an image.RGBA with its Pix in the arena. / NewRGBA выделяет image.RGBA с Pix в арене.
+//
+// Unlike image.NewRGBA the pixels are not zeroed; clear(img.Pix) if the
+// frame is not fully overwritten.
+func NewRGBA(a *Arena, r image.Rectangle) *image.RGBA {
+	return NewRGBAAligned(a, r, 0)
+}
+
+// NewRGBAAligned is NewRGBA with every row starting at a multiple of rowAlign
+// bytes (e.g. ImageRowAlign); 0 means tightly packed rows.
+// NewRGBAAligned — как NewRGBA, но каждая строка начинается с адреса, кратного rowAlign.
+func NewRGBAAligned(a *Arena, r image.Rectangle, rowAlign int) *image.RGBA {
+	pix, stride := allocPix(a, r, 4, rowAlign)
+	img := New[image.RGBA](a)
+	*img = image.RGBA{Pix: pix, Stride: stride, Rect: r}
+	return img
+}
+
+// NewNRGBA allocates an image.NRGBA with its Pix in the arena. / NewNRGBA выделяет image.NRGBA с Pix в арене.
+func NewNRGBA(a *Arena, r image.Rectangle) *image.NRGBA {
+	return NewNRGBAAligned(a, r, 0)
+}
+
+// NewNRGBAAligned is NewNRGBA with aligned rows. / NewNRGBAAligned — NewNRGBA с выровненными строками.
+func NewNRGBAAligned(a *Arena, r image.Rectangle, rowAlign int) *image.NRGBA {
+	pix, stride := allocPix(a, r, 4, rowAlign)
+	img := New[image.NRGBA](a)
+	*img = image.NRGBA{Pix: pix, Stride: stride, Rect: r}
+	return img
+}
+
+// NewGray allocates an image.Gray with its Pix in the arena. / NewGray выделяет image.Gray с Pix в арене.
+func NewGray(a *Arena, r image.Rectangle) *image.Gray {
+	return NewGrayAligned(a, r, 0)
+}
+
+// NewGrayAligned is NewGray with aligned rows. / NewGrayAligned — NewGray с выровненными строками.
+func NewGrayAligned(a *Arena, r image.Rectangle, rowAlign int) *image.Gray {
+	pix, stride := allocPix(a, r, 1, rowAlign)
+	img := New[image.Gray](a)
+	*img = image.Gray{Pix: pix, Stride: stride, Rect: r}
+	return img
+}
+
+// allocPix reserves a pixel buffer for r; with rowAlign > 0 both the base
+// address and the stride are multiples of rowAlign.
+// allocPix выделяет буфер пикселей; при rowAlign > 0 адрес и stride кратны rowAlign.
+func allocPix(a *Arena, r image.Rectangle, bytesPerPixel int, rowAlign int) ([]byte, int) {
+	if rowAlign < 0 || rowAlign&(rowAlign-1) != 0 {
+		panic("arena: image row alignment must be a power of two")
+	}
+	w, h := r.Dx(), r.Dy()
+	if w <= 0 || h <= 0 {
+		return nil, w * bytesPerPixel
+	}
+
+	stride := w * bytesPerPixel
+	if stride/bytesPerPixel != w {
+		panic("arena: image dimensions overflow")
+	}
+	if rowAlign > 1 {
+		stride = (stride + rowAlign - 1) &^ (rowAlign - 1)
+	}
+	total := stride * h
+	if total/h != stride {
+		panic("arena: image dimensions overflow")
+	}
+	if rowAlign < 1 {
+		rowAlign = 1
+	}
+	ptr := a.allocAligned(total, rowAlign)
+	return unsafe.Slice((*byte)(ptr), total), stride
+}
@@ -0,0 +1,50 @@
+package arena
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"unsafe"
+)
+
+func TestNewRGBAMatchesStdlibLayout(t *testing.T) {
+	a := NewArena(4096, 0)
+	r := image.Rect(2, 3, 12, 8)
+	img := NewRGBA(a, r)
+	std := image.NewRGBA(r)
+	if img.Stride != std.Stride || len(img.Pix) != len(std.Pix) || img.Rect != r {
+		t.Fatalf("layout mismatch: stride %d/%d len %d/%d", img.Stride, std.Stride, len(img.Pix), len(std.Pix))
+	}
+
+	c := color.RGBA{R: 1, G: 2, B: 3, A: 4}
+	img.Set(11, 7, c)
+	if got := img.RGBAAt(11, 7); got != c {
+		t.Fatalf("unexpected pixel: %+v", got)
+	}
+}
+
+func TestNewGrayAlignedRows(t *testing.T) {
+	a := NewArena(64, 0)
+	_ = a.AllocBytes(5) // misalign the cursor
+	img := NewGrayAligned(a, image.Rect(0, 0, 30, 4), ImageRowAlign)
+	if img.Stride != ImageRowAlign {
+		t.Fatalf("unexpected stride: got %d, want %d", img.Stride, ImageRowAlign)
+	}
+	for y := 0; y < 4; y++ {
+		addr := uintptr(unsafe.Pointer(&img.Pix[img.PixOffset(0, y)]))
+		if addr%ImageRowAlign != 0 {
+			t.Fatalf("row %d at 0x%x not aligned", y, addr)
+		}
+	}
+	img.SetGray(29, 3, color.Gray{Y: 9})
+	if img.GrayAt(29, 3).Y != 9 {
+		t.Fatal("unexpected pixel value in last row")
+	}
+}
+
+func TestNewNRGBAEmptyRect(t *testing.T) {
+	img := NewNRGBA(NewArena(64, 0), image.Rectangle{})
+	if img.Pix != nil || !img.Bounds().Empty() {
+		t.Fatalf("expected empty image, got %d pixels", len(img.Pix))
+	}
+}
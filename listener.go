@@ -0,0 +1,122 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+// ArenaListener wraps a net.Listener so that every accepted connection owns
+// a pooled arena for its lifetime.
+// ArenaListener оборачивает net.Listener: каждое принятое соединение получает арену из пула на все время жизни.
+type ArenaListener struct {
+	net.Listener
+	pool *ArenaPool
+}
+
+// NewArenaListener wraps l; arenas come from pool. / NewArenaListener оборачивает l; арены берутся из pool.
+func NewArenaListener(l net.Listener, pool *ArenaPool) *ArenaListener {
+	if l == nil || pool == nil {
+		panic("arena: NewArenaListener called with nil listener or pool")
+	}
+	return &ArenaListener{Listener: l, pool: pool}
+}
+
+// Accept waits for the next connection and returns it as an *ArenaConn.
+// Accept ожидает следующее соединение и возвращает его как *ArenaConn.
+func (l *ArenaListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewArenaConn(c, l.pool), nil
+}
+
+// Serve accepts connections and runs handler for each on its own goroutine,
+// closing the connection when handler returns. The arena is returned to the
+// pool only after both the handler has returned and the connection is
+// closed, so Close may be called from any goroutine (shutdown, idle timeouts)
+// while the handler still uses the arena. Serve returns Accept's error.
+// Serve принимает соединения и запускает handler для каждого; арена возвращается в пул только после выхода handler и закрытия соединения.
+func (l *ArenaListener) Serve(handler func(c *ArenaConn)) error {
+	if handler == nil {
+		panic("arena: Serve called with nil handler")
+	}
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		ac := c.(*ArenaConn)
+		ac.refs.Add(1) // Taken before the goroutine starts, so an early Close cannot Put. / До старта горутины.
+		go ac.serve(handler)
+	}
+}
+
+// ArenaConn is a net.Conn with its own arena, returned to the pool on Close.
+// ArenaConn — net.Conn со своей ареной, которая возвращается в пул при Close.
+//
+// The arena is not thread-safe: use Arena and ResetArena from the goroutine
+// that serves the connection. Under ArenaListener.Serve the arena outlives a
+// Close from another goroutine until the handler returns; otherwise Close
+// puts it back at once and must not race with the handler.
+type ArenaConn struct {
+	net.Conn
+	pool   *ArenaPool
+	arena  *Arena
+	refs   atomic.Int32 // The open connection plus a running Serve handler. / Открытое соединение и работающий handler.
+	closed atomic.Bool
+}
+
+// NewArenaConn attaches an arena from pool to c. / NewArenaConn привязывает к c арену из pool.
+func NewArenaConn(c net.Conn, pool *ArenaPool) *ArenaConn {
+	ac := &ArenaConn{Conn: c, pool: pool, arena: pool.Get()}
+	ac.refs.Store(1)
+	return ac
+}
+
+// Arena returns the connection's arena, or nil once it is back in the pool. / Arena возвращает арену соединения или nil после возврата в пул.
+func (c *ArenaConn) Arena() *Arena { return c.arena }
+
+// ResetArena frees everything allocated for the previous message. Call it
+// at each message boundary. / ResetArena освобождает все, что было выделено для предыдущего сообщения.
+func (c *ArenaConn) ResetArena() {
+	if c.arena != nil {
+		c.arena.Reset()
+	}
+}
+
+// Close closes the connection and drops its hold on the arena; the arena is
+// put back exactly once, when no Serve handler still runs.
+// Close закрывает соединение и отпускает арену; она возвращается в пул ровно один раз.
+func (c *ArenaConn) Close() error {
+	err := c.Conn.Close()
+	if c.closed.CompareAndSwap(false, true) {
+		c.release()
+	}
+	return err
+}
+
+func (c *ArenaConn) serve(handler func(c *ArenaConn)) {
+	defer c.release()
+	defer c.Close()
+	handler(c)
+}
+
+// release drops one reference; the last one puts the arena back. / release отпускает ссылку; последняя возвращает арену.
+func (c *ArenaConn) release() {
+	if c.refs.Add(-1) == 0 {
+		c.pool.Put(c.arena)
+		c.arena = nil
+	}
+}
+
+// ConnArena returns the arena attached to c, or nil if c is not an ArenaConn.
+// ConnArena возвращает арену соединения c или nil, если c не ArenaConn.
+func ConnArena(c net.Conn) *Arena {
+	if ac, ok := c.(*ArenaConn); ok {
+		return ac.Arena()
+	}
+	return nil
+}
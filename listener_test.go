@@ -0,0 +1,145 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+// pipeListener hands out one side of net.Pipe per Accept.
+type pipeListener struct {
+	conns chan net.Conn
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	c, ok := <-l.conns
+	if !ok {
+		return nil, net.ErrClosed
+	}
+	return c, nil
+}
+
+func (l *pipeListener) Close() error   { return nil }
+func (l *pipeListener) Addr() net.Addr { return &net.TCPAddr{} }
+
+func TestArenaListenerAssignsArenaPerConn(t *testing.T) {
+	pool := NewArenaPool(256, 0)
+	inner := &pipeListener{conns: make(chan net.Conn, 2)}
+	server1, client1 := net.Pipe()
+	server2, client2 := net.Pipe()
+	defer client1.Close()
+	defer client2.Close()
+	inner.conns <- server1
+	inner.conns <- server2
+	close(inner.conns)
+
+	l := NewArenaListener(inner, pool)
+	c1, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, _ := l.Accept()
+	a1, a2 := ConnArena(c1), ConnArena(c2)
+	if a1 == nil || a2 == nil || a1 == a2 {
+		t.Fatal("expected a distinct arena per connection")
+	}
+	if s := pool.MetricsSnapshot(); s.ActiveArenas != 2 {
+		t.Fatalf("expected 2 active arenas, got %d", s.ActiveArenas)
+	}
+
+	_ = a1.AllocString("message")
+	c1.(*ArenaConn).ResetArena()
+	if a1.UsedBytes() != 0 {
+		t.Fatalf("expected ResetArena to clear usage, got %d", a1.UsedBytes())
+	}
+
+	_ = c1.Close()
+	_ = c1.Close()
+	_ = c2.Close()
+	if s := pool.MetricsSnapshot(); s.ActiveArenas != 0 {
+		t.Fatalf("expected arenas returned once on Close, active=%d", s.ActiveArenas)
+	}
+	if ConnArena(c1) != nil {
+		t.Fatal("expected nil arena after Close")
+	}
+
+	if _, err := l.Accept(); err == nil {
+		t.Fatal("expected Accept error after inner listener is drained")
+	}
+}
+
+// Run with -race: under Serve, Close from another goroutine must not take
+// the arena away from a running handler.
+func TestArenaConnCloseFromAnotherGoroutine(t *testing.T) {
+	pool := NewArenaPool(256, 0)
+	server, client := net.Pipe()
+	defer client.Close()
+	c := NewArenaConn(server, pool)
+	c.refs.Add(1) // As Serve does before starting the handler.
+
+	ready, closed, done := make(chan struct{}), make(chan struct{}), make(chan error, 1)
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		c.serve(func(c *ArenaConn) {
+			msg := c.Arena().AllocString(string([]byte("in flight")))
+			close(ready)
+			if _, err := c.Read(make([]byte, 1)); err == nil {
+				done <- errors.New("expected Read to fail after Close")
+				return
+			}
+			<-closed
+			if msg != "in flight" || c.Arena() == nil {
+				done <- errors.New("arena was released while the handler used it")
+				return
+			}
+			_ = c.Arena().AllocString("more")
+			done <- nil
+		})
+	}()
+
+	<-ready
+	_ = c.Close()
+	if s := pool.MetricsSnapshot(); s.ActiveArenas != 1 {
+		t.Fatalf("expected arena held by the running handler, active=%d", s.ActiveArenas)
+	}
+	close(closed)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	<-exited
+	if s := pool.MetricsSnapshot(); s.ActiveArenas != 0 {
+		t.Fatalf("expected arena returned after handler exit, active=%d", s.ActiveArenas)
+	}
+}
+
+func TestArenaListenerServe(t *testing.T) {
+	pool := NewArenaPool(256, 0)
+	inner := &pipeListener{conns: make(chan net.Conn, 1)}
+	server, client := net.Pipe()
+	defer client.Close()
+	inner.conns <- server
+	close(inner.conns)
+
+	err := NewArenaListener(inner, pool).Serve(func(c *ArenaConn) {
+		_ = c.Arena().AllocString("hello")
+	})
+	if !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("expected Accept error from Serve, got %v", err)
+	}
+	// Blocks until Serve closes the connection after the handler returns.
+	if _, err := client.Write([]byte{1}); err == nil {
+		t.Fatal("expected connection to be closed after handler returned")
+	}
+}
+
+func TestConnArenaOnPlainConn(t *testing.T) {
+	c, other := net.Pipe()
+	defer c.Close()
+	defer other.Close()
+	if ConnArena(c) != nil {
+		t.Fatal("expected nil for a connection without an arena")
+	}
+}
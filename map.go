@@ -0,0 +1,254 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"hash/maphash"
+	"iter"
+	"reflect"
+	"unsafe"
+)
+
+const (
+	mapSlotEmpty uint8 = iota
+	mapSlotFull
+	mapSlotDeleted
+	mapSlotMoving // Live entry awaiting reinsertion during rehash. / Живая запись, ожидающая перевставки.
+)
+
+const mapMinCapacity = 8
+
+// Map is an open-addressing hash map whose slots live in the arena.
+// Map — хеш-таблица с открытой адресацией, слоты которой лежат в арене.
+//
+// Growing allocates new slot arrays and abandons the old ones until Reset,
+// just like Append; dropping tombstones reuses the current arrays. Keys and
+// values are stored in arena memory, so they must not hold the only
+// reference to heap objects. Not safe for concurrent use.
+type Map[K comparable, V any] struct {
+	a     *Arena
+	seed  maphash.Seed
+	ctrl  []uint8
+	keys  []K
+	vals  []V
+	count int // Live entries. / Живые записи.
+	used  int // Live entries plus tombstones. / Живые записи и надгробия.
+}
+
+// NewMap creates a map sized for capacityHint entries. / NewMap создает map под capacityHint записей.
+func NewMap[K comparable, V any](a *Arena, capacityHint int) *Map[K, V] {
+	if a == nil {
+		panic("arena: NewMap called with nil arena")
+	}
+	if capacityHint < 0 {
+		panic("arena: Map capacity hint must be non-negative")
+	}
+	m := &Map[K, V]{a: a, seed: maphash.MakeSeed()}
+	n := mapMinCapacity
+	for n*3/4 < capacityHint {
+		n *= 2
+	}
+	m.alloc(n)
+	return m
+}
+
+func (m *Map[K, V]) alloc(n int) {
+	m.ctrl = MakeSlice[uint8](m.a, n, n)
+	clear(m.ctrl) // Arena memory may hold stale bytes. / Память арены может содержать старые данные.
+	m.keys = MakeSlice[K](m.a, n, n)
+	m.vals = MakeSlice[V](m.a, n, n)
+	m.count = 0
+	m.used = 0
+}
+
+// find returns the slot holding key, or -1 and the first reusable slot.
+// find возвращает слот с ключом или -1 и первый пригодный для вставки слот.
+func (m *Map[K, V]) find(key K) (int, int) {
+	mask := len(m.ctrl) - 1
+	i := int(maphash.Comparable(m.seed, key)) & mask
+	insert := -1
+	for {
+		switch m.ctrl[i] {
+		case mapSlotEmpty:
+			if insert < 0 {
+				insert = i
+			}
+			return -1, insert
+		case mapSlotDeleted:
+			if insert < 0 {
+				insert = i
+			}
+		default:
+			if m.keys[i] == key {
+				return i, insert
+			}
+		}
+		i = (i + 1) & mask
+	}
+}
+
+// Set stores value for key. / Set сохраняет значение по ключу.
+func (m *Map[K, V]) Set(key K, value V) {
+	if i, _ := m.find(key); i >= 0 {
+		m.vals[i] = value
+		return
+	}
+	if (m.used+1)*4 > len(m.ctrl)*3 {
+		m.grow()
+	}
+	_, slot := m.find(key)
+	if m.ctrl[slot] == mapSlotEmpty {
+		m.used++
+	}
+	m.ctrl[slot] = mapSlotFull
+	m.keys[slot] = key
+	m.vals[slot] = value
+	m.count++
+}
+
+func (m *Map[K, V]) grow() {
+	if (m.count+1)*2 <= len(m.ctrl) {
+		// Mostly tombstones: reuse the arrays instead of abandoning them. / В основном надгробия: переиспользуем массивы.
+		m.rehashInPlace()
+		return
+	}
+	ctrl, keys, vals := m.ctrl, m.keys, m.vals
+	m.alloc(len(ctrl) * 2)
+	for i, c := range ctrl {
+		if c == mapSlotFull {
+			_, slot := m.find(keys[i])
+			m.ctrl[slot] = mapSlotFull
+			m.keys[slot] = keys[i]
+			m.vals[slot] = vals[i]
+			m.count++
+			m.used++
+		}
+	}
+}
+
+// rehashInPlace drops tombstones without allocating. Each displaced entry is
+// carried on until it lands in an empty slot, so every step settles one entry.
+// rehashInPlace убирает надгробия без аллокаций.
+func (m *Map[K, V]) rehashInPlace() {
+	for i, c := range m.ctrl {
+		if c == mapSlotFull {
+			m.ctrl[i] = mapSlotMoving
+		} else {
+			m.ctrl[i] = mapSlotEmpty
+		}
+	}
+	mask := len(m.ctrl) - 1
+	for i := range m.ctrl {
+		if m.ctrl[i] != mapSlotMoving {
+			continue
+		}
+		k, v := m.keys[i], m.vals[i]
+		m.ctrl[i] = mapSlotEmpty
+		for {
+			j := int(maphash.Comparable(m.seed, k)) & mask
+			for m.ctrl[j] == mapSlotFull {
+				j = (j + 1) & mask
+			}
+			moving := m.ctrl[j] == mapSlotMoving
+			m.ctrl[j] = mapSlotFull
+			m.keys[j], k = k, m.keys[j]
+			m.vals[j], v = v, m.vals[j]
+			if !moving {
+				break
+			}
+		}
+	}
+	m.used = m.count
+}
+
+// Get returns the value for key. / Get возвращает значение по ключу.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if i, _ := m.find(key); i >= 0 {
+		return m.vals[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was present. / Delete удаляет ключ и сообщает, был ли он.
+func (m *Map[K, V]) Delete(key K) bool {
+	i, _ := m.find(key)
+	if i < 0 {
+		return false
+	}
+	m.ctrl[i] = mapSlotDeleted
+	var zeroK K
+	var zeroV V
+	m.keys[i] = zeroK
+	m.vals[i] = zeroV
+	m.count--
+	return true
+}
+
+// Len returns the number of entries. / Len возвращает число записей.
+func (m *Map[K, V]) Len() int { return m.count }
+
+// All yields every entry in unspecified order. / All перебирает все записи в произвольном порядке.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i, c := range m.ctrl {
+			if c == mapSlotFull && !yield(m.keys[i], m.vals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSeq gathers seq into an arena slice. / CollectSeq собирает seq в слайс в арене.
+//
+// The GC does not scan arena memory. When T is a string type (named string
+// types included), every element is copied into the arena, so maps.Keys over
+// a map[string]V is safe. Other types must not hold the only reference to
+// heap objects.
+// GC не сканирует арену: строки копируются в арену, остальные типы не должны быть единственной ссылкой на объекты кучи.
+func CollectSeq[T any](a *Arena, seq iter.Seq[T]) []T {
+	copyStr := isStringType[T]()
+	var out []T
+	for v := range seq {
+		if copyStr {
+			v = arenaString(a, v)
+		}
+		out = Append(a, out, v)
+	}
+	return out
+}
+
+// CollectSeq2 gathers seq into an arena Map; later keys overwrite earlier ones.
+// CollectSeq2 собирает seq в Map в арене; повторные ключи перезаписываются.
+//
+// String keys and values are copied into the arena, as in CollectSeq; other
+// types must not hold the only reference to heap objects.
+// Строковые ключи и значения копируются в арену, как в CollectSeq.
+func CollectSeq2[K comparable, V any](a *Arena, seq iter.Seq2[K, V]) *Map[K, V] {
+	copyKey, copyVal := isStringType[K](), isStringType[V]()
+	m := NewMap[K, V](a, 0)
+	for k, v := range seq {
+		if copyKey {
+			k = arenaString(a, k)
+		}
+		if copyVal {
+			v = arenaString(a, v)
+		}
+		m.Set(k, v)
+	}
+	return m
+}
+
+// isStringType reports whether T's underlying type is string, named string
+// types included. / isStringType сообщает, является ли базовый тип T строкой.
+func isStringType[T any]() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.String
+}
+
+// arenaString copies v into the arena; T's underlying type must be string.
+// arenaString копирует v в арену; базовый тип T должен быть string.
+func arenaString[T any](a *Arena, v T) T {
+	p := (*string)(unsafe.Pointer(&v))
+	*p = a.AllocString(*p)
+	return v
+}
@@ -0,0 +1,135 @@
+//go:build !tinygo
+
+package arena
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMapSetGetDelete(t *testing.T) {
+	a := NewArena(4096, 0)
+	m := NewMap[string, int](a, 0)
+	for i := 0; i < 1000; i++ {
+		m.Set(a.AllocString(strconv.Itoa(i)), i)
+	}
+	m.Set("7", 700)
+	if m.Len() != 1000 {
+		t.Fatalf("unexpected len: got %d, want 1000", m.Len())
+	}
+	if v, ok := m.Get("7"); !ok || v != 700 {
+		t.Fatalf("Get(7) = %d, %v", v, ok)
+	}
+
+	for i := 0; i < 1000; i += 2 {
+		if !m.Delete(strconv.Itoa(i)) {
+			t.Fatalf("Delete(%d) reported missing key", i)
+		}
+	}
+	if m.Delete("0") || m.Len() != 500 {
+		t.Fatalf("unexpected state after deletes: len=%d", m.Len())
+	}
+	for i := 0; i < 1000; i++ {
+		_, ok := m.Get(strconv.Itoa(i))
+		if ok != (i%2 == 1) {
+			t.Fatalf("Get(%d) presence = %v", i, ok)
+		}
+	}
+}
+
+func TestMapChurnReusesTombstones(t *testing.T) {
+	a := NewArena(4096, 0)
+	m := NewMap[int, int](a, 4)
+	for i := 0; i < 3; i++ {
+		m.Set(-1-i, i) // Live entries that rehashing must keep. / Живые записи, которые должны пережить rehash.
+	}
+	used := a.UsedBytes()
+	for i := 0; i < 10000; i++ {
+		m.Set(i, i)
+		m.Delete(i)
+	}
+	if m.Len() != 3 || len(m.ctrl) > 16 {
+		t.Fatalf("expected table to stay small under churn, len=%d slots=%d", m.Len(), len(m.ctrl))
+	}
+	if a.UsedBytes() != used {
+		t.Fatalf("expected churn to reuse slot arrays, arena grew %d -> %d bytes", used, a.UsedBytes())
+	}
+	for i := 0; i < 3; i++ {
+		if v, ok := m.Get(-1 - i); !ok || v != i {
+			t.Fatalf("Get(%d) = %d, %v after rehash", -1-i, v, ok)
+		}
+	}
+}
+
+func TestCollectSeq(t *testing.T) {
+	a := NewArena(1024, 0)
+	src := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	keys := CollectSeq(a, maps.Keys(src))
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+	if got := CollectSeq(a, slices.Values([]int{})); got != nil {
+		t.Fatalf("expected nil for empty sequence, got %v", got)
+	}
+
+	m := CollectSeq2(a, maps.All(src))
+	if m.Len() != 3 {
+		t.Fatalf("unexpected map len: %d", m.Len())
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, src) {
+		t.Fatalf("unexpected map contents: %v", got)
+	}
+}
+
+type collectID string
+
+func TestCollectSeqCopiesRuntimeStrings(t *testing.T) {
+	a := NewArena(1<<16, 0)
+	src := make(map[string]string)
+	named := make(map[collectID]collectID)
+	for i := 0; i < 2000; i++ {
+		k, v := "key-"+strconv.Itoa(i), "val-"+strconv.Itoa(i)
+		src[k] = v
+		named[collectID(k+"-named")] = collectID(v + "-named")
+	}
+	keys := CollectSeq(a, maps.Keys(src))
+	m := CollectSeq2(a, maps.All(src))
+	namedKeys := CollectSeq(a, maps.Keys(named))
+	namedMap := CollectSeq2(a, maps.All(named))
+	src, named = nil, nil
+
+	// Drop the heap originals and reuse their memory. / Освобождаем оригиналы в куче и переиспользуем их память.
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		churn := make([][]byte, 0, 4096)
+		for j := 0; j < 4096; j++ {
+			churn = append(churn, []byte("xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"))
+		}
+		runtime.KeepAlive(churn)
+	}
+
+	seen := make(map[string]bool, len(keys)+len(namedKeys))
+	for _, k := range keys {
+		seen[k] = true
+	}
+	for _, k := range namedKeys {
+		seen[string(k)] = true
+	}
+	for i := 0; i < 2000; i++ {
+		k, v := "key-"+strconv.Itoa(i), "val-"+strconv.Itoa(i)
+		if !seen[k] || !seen[k+"-named"] {
+			t.Fatalf("CollectSeq lost key %q", k)
+		}
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("CollectSeq2: Get(%q) = %q, %v", k, got, ok)
+		}
+		if got, ok := namedMap.Get(collectID(k + "-named")); !ok || got != collectID(v+"-named") {
+			t.Fatalf("CollectSeq2 (named): Get(%q) = %q, %v", k, got, ok)
+		}
+	}
+}
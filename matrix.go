@@ -0,0 +1,42 @@
+package arena
+
+import "unsafe"
+
+// MakeMatrixAligned allocates a rows x cols float64 matrix whose backing
+// slice starts at a multiple of align bytes (a power of two; 64 suits AVX-512).
+// MakeMatrixAligned выделяет матрицу rows x cols, данные которой начинаются с адреса, кратного align.
+//
+// data is row-major with stride cols, so gonum can use it without copying:
+// mat.NewDense(rows, cols, data). rowViews[i] aliases data[i*cols:(i+1)*cols]
+// and is itself allocated in the arena. Memory is not zeroed.
+func MakeMatrixAligned(a *Arena, rows, cols, align int) (data []float64, rowViews [][]float64) {
+	if rows < 0 || cols < 0 {
+		panic("arena: matrix dimensions must be non-negative")
+	}
+	if align <= 0 || align&(align-1) != 0 {
+		panic("arena: alignment must be a power of two")
+	}
+	n := rows * cols
+	if cols != 0 && n/cols != rows {
+		panic("arena: matrix size overflow")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	elem := int(unsafe.Sizeof(float64(0)))
+	if n > int(^uint(0)>>1)/elem {
+		panic("arena: matrix size overflow")
+	}
+	if align < elem {
+		align = elem
+	}
+	ptr := a.allocAligned(n*elem, align)
+	data = unsafe.Slice((*float64)(ptr), n)
+
+	rowViews = MakeSlice[[]float64](a, rows, rows)
+	for i := range rowViews {
+		rowViews[i] = data[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return data, rowViews
+}
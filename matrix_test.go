@@ -0,0 +1,39 @@
+package arena
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestMakeMatrixAligned(t *testing.T) {
+	a := NewArena(256, 0)
+	_ = a.AllocBytes(3) // misalign the cursor
+	data, rows := MakeMatrixAligned(a, 3, 5, 64)
+	if len(data) != 15 || len(rows) != 3 {
+		t.Fatalf("unexpected shape: data=%d rows=%d", len(data), len(rows))
+	}
+	if addr := uintptr(unsafe.Pointer(&data[0])); addr%64 != 0 {
+		t.Fatalf("data at 0x%x not 64-byte aligned", addr)
+	}
+
+	rows[1][4] = 42
+	if data[1*5+4] != 42 {
+		t.Fatal("row view must alias the flat backing slice")
+	}
+	if cap(rows[0]) != 5 {
+		t.Fatalf("row view cap must not spill into next row, got %d", cap(rows[0]))
+	}
+}
+
+func TestMakeMatrixAlignedEdgeCases(t *testing.T) {
+	a := NewArena(64, 0)
+	if data, rows := MakeMatrixAligned(a, 0, 4, 32); data != nil || rows != nil {
+		t.Fatal("expected nil slices for empty matrix")
+	}
+	mustPanic(t, "non power of two", func() {
+		_, _ = MakeMatrixAligned(a, 2, 2, 48)
+	})
+	mustPanic(t, "negative rows", func() {
+		_, _ = MakeMatrixAligned(a, -1, 2, 8)
+	})
+}
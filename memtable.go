@@ -0,0 +1,88 @@
+package arena
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// MemtableFlushFunc receives the memtable contents in key order. The entries
+// point into the arena and are invalid once the callback returns.
+// MemtableFlushFunc получает содержимое memtable по порядку ключей; данные валидны только внутри вызова.
+type MemtableFlushFunc func(entries iter.Seq2[string, string]) error
+
+// Memtable is a sorted key/value buffer over a SkipList. When the arena
+// holds threshold bytes it calls the flush callback and resets the arena.
+// Memtable — отсортированный буфер ключ/значение поверх SkipList; при достижении порога вызывает flush и сбрасывает арену.
+//
+// Memtable owns the arena: nothing else may allocate from it. Memtable is
+// not safe for concurrent use, reads included: any Put may flush, which
+// resets the arena and replaces the skip list under a running Get.
+// Memtable не потокобезопасен, даже для чтения: любой Put может вызвать flush и сбросить арену.
+type Memtable struct {
+	a         *Arena
+	list      *SkipList[string, string]
+	threshold int
+	flush     MemtableFlushFunc
+}
+
+// NewMemtable creates a memtable flushing at threshold arena bytes. / NewMemtable создает memtable со сбросом при threshold байт в арене.
+func NewMemtable(a *Arena, threshold int, flush MemtableFlushFunc) *Memtable {
+	if a == nil {
+		panic("arena: NewMemtable called with nil arena")
+	}
+	if threshold <= 0 {
+		panic("arena: Memtable threshold must be positive")
+	}
+	if flush == nil {
+		panic("arena: NewMemtable called with nil flush callback")
+	}
+	return &Memtable{
+		a:         a,
+		list:      NewSkipList[string, string](a),
+		threshold: threshold,
+		flush:     flush,
+	}
+}
+
+// Put copies key and value into the arena and inserts them, flushing if the
+// threshold is reached. The error comes from the flush callback.
+// Put копирует ключ и значение в арену и вставляет их; при достижении порога выполняет flush.
+func (m *Memtable) Put(key, value string) error {
+	m.list.Insert(m.a.AllocString(key), m.a.AllocString(value))
+	if m.a.UsedBytes() >= m.threshold {
+		return m.Flush()
+	}
+	return nil
+}
+
+// PutBytes is Put for byte slices. / PutBytes — Put для байтовых слайсов.
+func (m *Memtable) PutBytes(key, value []byte) error {
+	return m.Put(unsafe.String(unsafe.SliceData(key), len(key)), unsafe.String(unsafe.SliceData(value), len(value)))
+}
+
+// Get returns the value for key; it is valid until the next flush.
+// Get возвращает значение по ключу; оно валидно до следующего flush.
+func (m *Memtable) Get(key string) (string, bool) {
+	return m.list.Get(key)
+}
+
+// Len returns the number of keys. / Len возвращает число ключей.
+func (m *Memtable) Len() int { return m.list.Len() }
+
+// SizeBytes returns the arena bytes in use. / SizeBytes возвращает занятые байты арены.
+func (m *Memtable) SizeBytes() int { return m.a.UsedBytes() }
+
+// Flush hands the contents to the callback, then resets the arena. If the
+// callback fails, the contents are kept and the error is returned.
+// Flush передает содержимое в callback и сбрасывает арену; при ошибке данные сохраняются.
+func (m *Memtable) Flush() error {
+	if m.list.Len() == 0 {
+		return nil
+	}
+	if err := m.flush(m.list.All()); err != nil {
+		return err
+	}
+	m.a.Reset()
+	m.list = NewSkipList[string, string](m.a)
+	return nil
+}
@@ -0,0 +1,69 @@
+package arena
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"testing"
+)
+
+func TestMemtableFlushesSortedAtThreshold(t *testing.T) {
+	var flushes [][]string
+	m := NewMemtable(NewArena(1024, 0), 2048, func(entries iter.Seq2[string, string]) error {
+		var keys []string
+		for k, v := range entries {
+			if v != "v-"+k {
+				return fmt.Errorf("bad value %q for %q", v, k)
+			}
+			keys = append(keys, string([]byte(k))) // copy out of the arena
+		}
+		flushes = append(flushes, keys)
+		return nil
+	})
+
+	for i := 0; i < 200; i++ {
+		k := fmt.Sprintf("key-%03d", (i*37)%200)
+		if err := m.Put(k, "v-"+k); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if len(flushes) == 0 {
+		t.Fatal("expected threshold to trigger at least one flush")
+	}
+	if m.SizeBytes() >= 2048 {
+		t.Fatalf("expected arena to be reset after flush, used %d", m.SizeBytes())
+	}
+	for i, keys := range flushes {
+		if !sort.StringsAreSorted(keys) {
+			t.Fatalf("flush %d not sorted", i)
+		}
+	}
+
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, keys := range flushes {
+		total += len(keys)
+	}
+	if total != 200 || m.Len() != 0 {
+		t.Fatalf("expected all 200 keys flushed, got %d (len=%d)", total, m.Len())
+	}
+}
+
+func TestMemtableKeepsDataOnFlushError(t *testing.T) {
+	errFull := errors.New("disk full")
+	m := NewMemtable(NewArena(256, 0), 1<<20, func(iter.Seq2[string, string]) error {
+		return errFull
+	})
+	if err := m.PutBytes([]byte("k"), []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Flush(); !errors.Is(err, errFull) {
+		t.Fatalf("expected flush error, got %v", err)
+	}
+	if v, ok := m.Get("k"); !ok || v != "v" {
+		t.Fatalf("expected data to survive failed flush, got %q %v", v, ok)
+	}
+}
@@ -1,9 +1,6 @@
 package arena
 
-import (
-	"sync"
-	"sync/atomic"
-)
+import "sync/atomic"
 
 type PoolMetrics struct {
 	TotalCapacityBytes atomic.Uint64
@@ -26,9 +23,10 @@ type PoolMetricsSnapshot struct {
 
 // ArenaPool stores and reuses arenas. / ArenaPool хранит и переиспользует арены.
 type ArenaPool struct {
-	pool        sync.Pool
+	cache       arenaCache // sync.Pool, or a free list under tinygo/arenafreelist. / sync.Pool или free list при tinygo/arenafreelist.
 	chunkSize   int
 	maxRetained int
+	debug       poolDebugState
 	Metrics     PoolMetrics
 }
 
@@ -45,10 +43,10 @@ func NewArenaPool(chunkSize int, maxRetained int) *ArenaPool {
 		chunkSize:   chunkSize,
 		maxRetained: maxRetained,
 	}
-	p.pool.New = func() any {
+	p.cache.init(func() *Arena {
 		p.Metrics.TotalCapacityBytes.Add(uint64(chunkSize))
 		return NewArena(p.chunkSize, p.maxRetained)
-	}
+	})
 	return p
 }
 
@@ -56,7 +54,7 @@ func NewArenaPool(chunkSize int, maxRetained int) *ArenaPool {
 func (p *ArenaPool) Get() *Arena {
 	p.Metrics.GetCount.Add(1)
 	p.Metrics.ActiveArenas.Add(1)
-	return p.pool.Get().(*Arena)
+	return p.cache.get()
 }
 
 // Put returns an arena to the pool. / Put возвращает арену в пул.
@@ -67,8 +65,11 @@ func (p *ArenaPool) Put(a *Arena) {
 	used := uint64(a.UsedBytes())
 	p.Metrics.TotalUsedBytes.Add(used)
 	p.Metrics.ActiveArenas.Add(-1)
+	if debugTracking {
+		p.debug.collect(&a.debug)
+	}
 	a.Reset()
-	p.pool.Put(a)
+	p.cache.put(a)
 }
 
 // MetricsSnapshot returns a copy of current metrics values (atomic-safe read).
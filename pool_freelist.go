@@ -0,0 +1,43 @@
+//go:build tinygo || arenafreelist
+
+package arena
+
+import "sync"
+
+// freeListMaxIdle bounds idle arenas kept by the free list. / freeListMaxIdle ограничивает число свободных арен в списке.
+const freeListMaxIdle = 64
+
+// arenaCache is a mutex-guarded free list for runtimes where sync.Pool is
+// missing or never releases memory (TinyGo, embedded targets). Arenas beyond
+// freeListMaxIdle are dropped on put.
+// arenaCache — free list под мьютексом для сред без полноценного sync.Pool (TinyGo, embedded).
+type arenaCache struct {
+	mu       sync.Mutex
+	free     []*Arena
+	newArena func() *Arena
+}
+
+func (c *arenaCache) init(newArena func() *Arena) {
+	c.newArena = newArena
+}
+
+func (c *arenaCache) get() *Arena {
+	c.mu.Lock()
+	if n := len(c.free); n > 0 {
+		a := c.free[n-1]
+		c.free[n-1] = nil
+		c.free = c.free[:n-1]
+		c.mu.Unlock()
+		return a
+	}
+	c.mu.Unlock()
+	return c.newArena()
+}
+
+func (c *arenaCache) put(a *Arena) {
+	c.mu.Lock()
+	if len(c.free) < freeListMaxIdle {
+		c.free = append(c.free, a)
+	}
+	c.mu.Unlock()
+}
@@ -0,0 +1,29 @@
+//go:build tinygo || arenafreelist
+
+package arena
+
+import "testing"
+
+// Run with: go test -tags arenafreelist
+func TestFreeListPoolReusesArenas(t *testing.T) {
+	p := NewArenaPool(256, 0)
+	a := p.Get()
+	p.Put(a)
+	if b := p.Get(); b != a {
+		t.Fatal("expected free list to hand back the returned arena")
+	}
+}
+
+func TestFreeListPoolBoundsIdleArenas(t *testing.T) {
+	p := NewArenaPool(64, 0)
+	arenas := make([]*Arena, freeListMaxIdle+10)
+	for i := range arenas {
+		arenas[i] = p.Get()
+	}
+	for _, a := range arenas {
+		p.Put(a)
+	}
+	if n := len(p.cache.free); n != freeListMaxIdle {
+		t.Fatalf("expected %d idle arenas, got %d", freeListMaxIdle, n)
+	}
+}
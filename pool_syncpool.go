@@ -0,0 +1,19 @@
+//go:build !tinygo && !arenafreelist
+
+package arena
+
+import "sync"
+
+// arenaCache keeps idle arenas in a sync.Pool, letting the GC drop them under pressure.
+// arenaCache хранит свободные арены в sync.Pool, позволяя GC освобождать их.
+type arenaCache struct {
+	pool sync.Pool
+}
+
+func (c *arenaCache) init(newArena func() *Arena) {
+	c.pool.New = func() any { return newArena() }
+}
+
+func (c *arenaCache) get() *Arena { return c.pool.Get().(*Arena) }
+
+func (c *arenaCache) put(a *Arena) { c.pool.Put(a) }
@@ -0,0 +1,133 @@
+package arena
+
+import (
+	"io"
+	"unsafe"
+)
+
+const defaultRopeSegmentSize = 64 * 1024
+
+type ropeSegment struct {
+	data []byte
+	next *ropeSegment
+}
+
+// Rope accumulates large text as a list of arena segments. Appends are O(1)
+// amortized, Concat is O(1), and no step needs one huge contiguous buffer.
+// Rope накапливает большой текст списком сегментов в арене; добавление и Concat — O(1).
+type Rope struct {
+	a       *Arena
+	head    *ropeSegment
+	tail    *ropeSegment
+	length  int
+	segSize int
+}
+
+// NewRope creates an empty rope; segmentSize <= 0 selects 64KB segments.
+// NewRope создает пустой rope; при segmentSize <= 0 сегменты по 64KB.
+func NewRope(a *Arena, segmentSize int) *Rope {
+	if a == nil {
+		panic("arena: NewRope called with nil arena")
+	}
+	if segmentSize <= 0 {
+		segmentSize = defaultRopeSegmentSize
+	}
+	return &Rope{a: a, segSize: segmentSize}
+}
+
+// Len returns the total length in bytes. / Len возвращает общую длину в байтах.
+func (r *Rope) Len() int { return r.length }
+
+// Write appends p; it never fails. / Write добавляет p; ошибок не бывает.
+func (r *Rope) Write(p []byte) (int, error) {
+	r.append(unsafe.String(unsafe.SliceData(p), len(p)))
+	return len(p), nil
+}
+
+// WriteString appends s; it never fails. / WriteString добавляет s; ошибок не бывает.
+func (r *Rope) WriteString(s string) (int, error) {
+	r.append(s)
+	return len(s), nil
+}
+
+// WriteByte appends c; it never fails. / WriteByte добавляет c; ошибок не бывает.
+func (r *Rope) WriteByte(c byte) error {
+	r.append(unsafe.String(&c, 1))
+	return nil
+}
+
+func (r *Rope) append(s string) {
+	if len(s) == 0 {
+		return
+	}
+	r.length += len(s)
+	if t := r.tail; t != nil {
+		n := copy(t.data[len(t.data):cap(t.data)], s)
+		t.data = t.data[:len(t.data)+n]
+		s = s[n:]
+		if len(s) == 0 {
+			return
+		}
+	}
+
+	size := r.segSize
+	if len(s) > size {
+		size = len(s)
+	}
+	seg := New[ropeSegment](r.a)
+	*seg = ropeSegment{data: r.a.AllocBytes(size)[:0]}
+	seg.data = append(seg.data, s...)
+	r.link(seg, seg)
+}
+
+func (r *Rope) link(head, tail *ropeSegment) {
+	if r.tail == nil {
+		r.head = head
+	} else {
+		r.tail.next = head
+	}
+	r.tail = tail
+}
+
+// Concat moves all of other's segments to the end of r in O(1) and empties
+// other. Both ropes must use the same arena (or arenas with the same lifetime).
+// Concat за O(1) переносит сегменты other в конец r и опустошает other.
+func (r *Rope) Concat(other *Rope) {
+	if other == r || other.head == nil {
+		return
+	}
+	r.link(other.head, other.tail)
+	r.length += other.length
+	other.head, other.tail, other.length = nil, nil, 0
+}
+
+// Reset empties the rope without touching the arena. / Reset опустошает rope, не трогая арену.
+func (r *Rope) Reset() {
+	r.head, r.tail, r.length = nil, nil, 0
+}
+
+// String materializes the rope into one arena string. / String собирает rope в одну строку в арене.
+func (r *Rope) String() string {
+	if r.length == 0 {
+		return ""
+	}
+	buf := r.a.AllocBytes(r.length)
+	n := 0
+	for seg := r.head; seg != nil; seg = seg.next {
+		n += copy(buf[n:], seg.data)
+	}
+	return unsafe.String(unsafe.SliceData(buf), len(buf))
+}
+
+// WriteTo writes every segment to w in order. / WriteTo последовательно пишет все сегменты в w.
+func (r *Rope) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for seg := r.head; seg != nil; seg = seg.next {
+		n, err := w.Write(seg.data)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,66 @@
+package arena
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRopeAppendAndMaterialize(t *testing.T) {
+	a := NewArena(1024, 0)
+	r := NewRope(a, 16)
+	var want strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(r, "line-%d;", i)
+		fmt.Fprintf(&want, "line-%d;", i)
+	}
+	r.WriteByte('!')
+	want.WriteByte('!')
+
+	if r.Len() != want.Len() {
+		t.Fatalf("unexpected len: got %d, want %d", r.Len(), want.Len())
+	}
+	if got := r.String(); got != want.String() {
+		t.Fatalf("unexpected content: %q", got)
+	}
+
+	var out bytes.Buffer
+	n, err := r.WriteTo(&out)
+	if err != nil || n != int64(want.Len()) || out.String() != want.String() {
+		t.Fatalf("WriteTo: n=%d err=%v", n, err)
+	}
+}
+
+func TestRopeLargeWriteGetsOwnSegment(t *testing.T) {
+	r := NewRope(NewArena(64, 0), 8)
+	big := strings.Repeat("x", 100)
+	r.WriteString("ab")
+	r.WriteString(big)
+	r.WriteString("cd")
+	if got := r.String(); got != "ab"+big+"cd" {
+		t.Fatalf("unexpected content length %d", len(got))
+	}
+}
+
+func TestRopeConcat(t *testing.T) {
+	a := NewArena(1024, 0)
+	left, right := NewRope(a, 8), NewRope(a, 8)
+	left.WriteString("hello, ")
+	right.WriteString("world")
+
+	left.Concat(right)
+	left.WriteString("!")
+	if got := left.String(); got != "hello, world!" {
+		t.Fatalf("unexpected concat result: %q", got)
+	}
+	if right.Len() != 0 || right.String() != "" {
+		t.Fatal("expected other rope to be emptied by Concat")
+	}
+
+	empty := NewRope(a, 8)
+	empty.Concat(left)
+	if empty.String() != "hello, world!" {
+		t.Fatalf("unexpected concat into empty rope: %q", empty.String())
+	}
+}
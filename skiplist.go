@@ -0,0 +1,198 @@
+package arena
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"sync/atomic"
+)
+
+const skipListMaxHeight = 20
+
+type skipNode[K, V any] struct {
+	key  K
+	val  atomic.Pointer[V]
+	next []atomic.Pointer[skipNode[K, V]] // Tower, one link per level. / Башня ссылок по уровням.
+}
+
+// SkipList is an ordered map whose nodes live in the arena.
+// SkipList — упорядоченная map, узлы которой лежат в арене.
+//
+// One writer may Insert while any number of goroutines read (Get, Seek,
+// iteration) without locks: nodes are fully built before being linked in
+// with atomic stores. Writers must be serialized by the caller, and the
+// arena must not be Reset while readers are active.
+//
+// Keys and values are stored in arena memory, so they must not hold the only
+// reference to heap objects (copy strings into the arena first).
+type SkipList[K, V any] struct {
+	a      *Arena
+	cmp    func(K, K) int
+	head   *skipNode[K, V]
+	height atomic.Int32
+	length atomic.Int64
+	rng    uint64
+}
+
+// NewSkipList creates a skip list for ordered keys. / NewSkipList создает skip list для упорядоченных ключей.
+func NewSkipList[K cmp.Ordered, V any](a *Arena) *SkipList[K, V] {
+	return NewSkipListFunc[K, V](a, cmp.Compare[K])
+}
+
+// NewSkipListFunc creates a skip list with a custom comparator. / NewSkipListFunc создает skip list со своим компаратором.
+func NewSkipListFunc[K, V any](a *Arena, compare func(K, K) int) *SkipList[K, V] {
+	if a == nil {
+		panic("arena: NewSkipList called with nil arena")
+	}
+	if compare == nil {
+		panic("arena: NewSkipListFunc called with nil comparator")
+	}
+	s := &SkipList[K, V]{
+		a:   a,
+		cmp: compare,
+		rng: 0x9e3779b97f4a7c15,
+	}
+	var zero K
+	s.head = s.newNode(zero, skipListMaxHeight)
+	s.height.Store(1)
+	return s
+}
+
+func (s *SkipList[K, V]) newNode(key K, height int) *skipNode[K, V] {
+	n := New[skipNode[K, V]](s.a)
+	// Arena memory is not zeroed, so build every field explicitly. / Память арены не обнулена, заполняем все поля явно.
+	n.key = key
+	n.val.Store(nil)
+	n.next = MakeSlice[atomic.Pointer[skipNode[K, V]]](s.a, height, height)
+	for i := range n.next {
+		n.next[i].Store(nil)
+	}
+	return n
+}
+
+// randomHeight draws a level with p = 1/4 per extra level. / randomHeight выбирает высоту с p = 1/4 на уровень.
+func (s *SkipList[K, V]) randomHeight() int {
+	s.rng ^= s.rng << 13
+	s.rng ^= s.rng >> 7
+	s.rng ^= s.rng << 17
+	h := 1 + bits.TrailingZeros64(s.rng|1<<62)/2
+	if h > skipListMaxHeight {
+		h = skipListMaxHeight
+	}
+	return h
+}
+
+// findGE returns the first node with key >= key, filling preds when non-nil.
+// findGE возвращает первый узел с ключом >= key и заполняет preds.
+func (s *SkipList[K, V]) findGE(key K, preds *[skipListMaxHeight]*skipNode[K, V]) *skipNode[K, V] {
+	x := s.head
+	for level := int(s.height.Load()) - 1; level >= 0; level-- {
+		for {
+			next := x.next[level].Load()
+			if next == nil || s.cmp(next.key, key) >= 0 {
+				break
+			}
+			x = next
+		}
+		if preds != nil {
+			preds[level] = x
+		}
+	}
+	return x.next[0].Load()
+}
+
+// Insert sets key to value and reports whether the key is new.
+// Insert записывает значение по ключу и сообщает, был ли ключ новым.
+func (s *SkipList[K, V]) Insert(key K, value V) bool {
+	vp := New[V](s.a)
+	*vp = value
+
+	var preds [skipListMaxHeight]*skipNode[K, V]
+	if n := s.findGE(key, &preds); n != nil && s.cmp(n.key, key) == 0 {
+		n.val.Store(vp)
+		return false
+	}
+
+	h := s.randomHeight()
+	if cur := int(s.height.Load()); h > cur {
+		for level := cur; level < h; level++ {
+			preds[level] = s.head
+		}
+		s.height.Store(int32(h))
+	}
+
+	n := s.newNode(key, h)
+	n.val.Store(vp)
+	for level := 0; level < h; level++ {
+		n.next[level].Store(preds[level].next[level].Load())
+	}
+	// Publish bottom-up so readers never see a half-linked node. / Публикуем снизу вверх.
+	for level := 0; level < h; level++ {
+		preds[level].next[level].Store(n)
+	}
+	s.length.Add(1)
+	return true
+}
+
+// Get returns the value stored for key. / Get возвращает значение по ключу.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if n := s.findGE(key, nil); n != nil && s.cmp(n.key, key) == 0 {
+		return *n.val.Load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Len returns the number of keys. / Len возвращает число ключей.
+func (s *SkipList[K, V]) Len() int {
+	return int(s.length.Load())
+}
+
+// Seek returns an iterator at the first key >= key. / Seek возвращает итератор на первом ключе >= key.
+func (s *SkipList[K, V]) Seek(key K) SkipListIterator[K, V] {
+	return SkipListIterator[K, V]{n: s.findGE(key, nil)}
+}
+
+// First returns an iterator at the smallest key. / First возвращает итератор на наименьшем ключе.
+func (s *SkipList[K, V]) First() SkipListIterator[K, V] {
+	return SkipListIterator[K, V]{n: s.head.next[0].Load()}
+}
+
+// All yields every entry in key order. / All перебирает все записи по порядку ключей.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := s.First(); it.Valid(); it.Next() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Range yields entries with from <= key < to in key order. / Range перебирает записи с from <= key < to.
+func (s *SkipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := s.Seek(from); it.Valid() && s.cmp(it.Key(), to) < 0; it.Next() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// SkipListIterator walks a SkipList in key order. / SkipListIterator обходит SkipList по порядку ключей.
+type SkipListIterator[K, V any] struct {
+	n *skipNode[K, V]
+}
+
+// Valid reports whether the iterator points at an entry. / Valid сообщает, указывает ли итератор на запись.
+func (it *SkipListIterator[K, V]) Valid() bool { return it.n != nil }
+
+// Next advances to the following key. / Next переходит к следующему ключу.
+func (it *SkipListIterator[K, V]) Next() { it.n = it.n.next[0].Load() }
+
+// Key returns the current key. / Key возвращает текущий ключ.
+func (it *SkipListIterator[K, V]) Key() K { return it.n.key }
+
+// Value returns the current value. / Value возвращает текущее значение.
+func (it *SkipListIterator[K, V]) Value() V { return *it.n.val.Load() }
@@ -0,0 +1,113 @@
+package arena
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSkipListInsertGetAndOrder(t *testing.T) {
+	a := NewArena(4096, 0)
+	s := NewSkipList[int, string](a)
+	r := rand.New(rand.NewSource(1))
+	want := map[int]string{}
+	for i := 0; i < 2000; i++ {
+		k := r.Intn(1000)
+		v := a.AllocString("v")
+		if isNew := s.Insert(k, v); isNew == (want[k] != "") {
+			t.Fatalf("key %d: unexpected isNew=%v", k, isNew)
+		}
+		want[k] = v
+	}
+	if s.Len() != len(want) {
+		t.Fatalf("unexpected len: got %d, want %d", s.Len(), len(want))
+	}
+
+	keys := make([]int, 0, len(want))
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+	if !sort.IntsAreSorted(keys) || len(keys) != len(want) {
+		t.Fatalf("iteration not sorted or incomplete: %d keys", len(keys))
+	}
+	for k, v := range want {
+		if got, ok := s.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %q, %v", k, got, ok)
+		}
+	}
+	if _, ok := s.Get(-1); ok {
+		t.Fatal("unexpected hit for missing key")
+	}
+}
+
+func TestSkipListUpdateKeepsSingleEntry(t *testing.T) {
+	s := NewSkipList[string, int](NewArena(1024, 0))
+	s.Insert("a", 1)
+	s.Insert("a", 2)
+	if v, _ := s.Get("a"); v != 2 || s.Len() != 1 {
+		t.Fatalf("unexpected state after update: v=%d len=%d", v, s.Len())
+	}
+}
+
+func TestSkipListSeekAndRange(t *testing.T) {
+	s := NewSkipList[int, int](NewArena(1024, 0))
+	for i := 0; i < 100; i += 10 {
+		s.Insert(i, i*i)
+	}
+	it := s.Seek(35)
+	if !it.Valid() || it.Key() != 40 || it.Value() != 1600 {
+		t.Fatalf("Seek(35) landed on %d", it.Key())
+	}
+	if it := s.Seek(1000); it.Valid() {
+		t.Fatal("expected exhausted iterator past the last key")
+	}
+
+	var got []int
+	for k := range s.Range(20, 50) {
+		got = append(got, k)
+	}
+	if len(got) != 3 || got[0] != 20 || got[2] != 40 {
+		t.Fatalf("unexpected range: %v", got)
+	}
+}
+
+// TestSkipListConcurrentReaders runs lock-free readers against a single writer.
+// Run with: go test -race -run TestSkipListConcurrentReaders
+func TestSkipListConcurrentReaders(t *testing.T) {
+	s := NewSkipList[int, int](NewArena(64*1024, 0))
+	const n = 5000
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				prev := -1
+				for k, v := range s.All() {
+					if k <= prev || v != k {
+						t.Errorf("inconsistent read: k=%d v=%d prev=%d", k, v, prev)
+						return
+					}
+					prev = k
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		k := (i * 7919) % n
+		s.Insert(k, k)
+	}
+	close(stop)
+	wg.Wait()
+	if s.Len() != n {
+		t.Fatalf("unexpected len: got %d, want %d", s.Len(), n)
+	}
+}
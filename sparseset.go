@@ -0,0 +1,74 @@
+package arena
+
+// SparseSet is a set of integers in [0, universe) with O(1) add, remove and
+// contains, and dense iteration order. Both arrays live in the arena.
+// SparseSet — множество целых из [0, universe) с O(1) добавлением, удалением и проверкой; оба массива лежат в арене.
+//
+// The sparse array is never cleared: membership is confirmed through the
+// dense array, so dirty arena memory is harmless and Clear is O(1).
+type SparseSet struct {
+	dense  []uint32
+	sparse []uint32
+}
+
+// NewSparseSet creates an empty set for keys below universe. / NewSparseSet создает пустое множество для ключей меньше universe.
+func NewSparseSet(a *Arena, universe int) *SparseSet {
+	if universe < 0 || uint64(universe) > 1<<32 {
+		panic("arena: SparseSet universe out of range")
+	}
+	s := New[SparseSet](a)
+	*s = SparseSet{
+		dense:  MakeSlice[uint32](a, 0, universe),
+		sparse: MakeSlice[uint32](a, universe, universe),
+	}
+	return s
+}
+
+// Contains reports whether x is in the set. / Contains сообщает, входит ли x в множество.
+func (s *SparseSet) Contains(x uint32) bool {
+	if int(x) >= len(s.sparse) {
+		return false
+	}
+	i := s.sparse[x]
+	return int(i) < len(s.dense) && s.dense[i] == x
+}
+
+// Add inserts x and reports whether it was absent. Panics if x is outside the universe.
+// Add добавляет x и сообщает, отсутствовал ли он. Паникует, если x вне диапазона.
+func (s *SparseSet) Add(x uint32) bool {
+	if int(x) >= len(s.sparse) {
+		panic("arena: SparseSet key out of range")
+	}
+	if s.Contains(x) {
+		return false
+	}
+	s.sparse[x] = uint32(len(s.dense))
+	s.dense = append(s.dense, x) // Within capacity: dense cap equals universe. / В пределах cap.
+	return true
+}
+
+// Remove deletes x and reports whether it was present. / Remove удаляет x и сообщает, присутствовал ли он.
+func (s *SparseSet) Remove(x uint32) bool {
+	if !s.Contains(x) {
+		return false
+	}
+	i := s.sparse[x]
+	last := s.dense[len(s.dense)-1]
+	s.dense[i] = last
+	s.sparse[last] = i
+	s.dense = s.dense[:len(s.dense)-1]
+	return true
+}
+
+// Len returns the number of elements. / Len возвращает число элементов.
+func (s *SparseSet) Len() int { return len(s.dense) }
+
+// Universe returns the exclusive upper bound for keys. / Universe возвращает верхнюю (не включительно) границу ключей.
+func (s *SparseSet) Universe() int { return len(s.sparse) }
+
+// Clear removes all elements in O(1). / Clear удаляет все элементы за O(1).
+func (s *SparseSet) Clear() { s.dense = s.dense[:0] }
+
+// Values returns the elements in dense order; the slice is only valid until
+// the next Add or Remove. / Values возвращает элементы; слайс валиден до следующего Add или Remove.
+func (s *SparseSet) Values() []uint32 { return s.dense }
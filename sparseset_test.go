@@ -0,0 +1,59 @@
+package arena
+
+import "testing"
+
+func TestSparseSetAddRemoveContains(t *testing.T) {
+	a := NewArena(1024, 0)
+	s := NewSparseSet(a, 100)
+	for _, x := range []uint32{5, 42, 99, 0} {
+		if !s.Add(x) {
+			t.Fatalf("Add(%d) reported duplicate", x)
+		}
+	}
+	if s.Add(42) {
+		t.Fatal("expected duplicate Add to return false")
+	}
+	if s.Len() != 4 || !s.Contains(99) || s.Contains(7) || s.Contains(1000) {
+		t.Fatalf("unexpected membership, len=%d", s.Len())
+	}
+
+	if !s.Remove(5) || s.Remove(5) {
+		t.Fatal("unexpected Remove result")
+	}
+	if s.Contains(5) || !s.Contains(0) || !s.Contains(42) || s.Len() != 3 {
+		t.Fatalf("unexpected state after Remove: %v", s.Values())
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Contains(42) {
+		t.Fatal("expected empty set after Clear")
+	}
+}
+
+func TestSparseSetIgnoresDirtyArenaMemory(t *testing.T) {
+	a := NewArena(1024, 0)
+	// Stale sparse entries pointing at valid dense indices. / Старые значения, указывающие на валидные индексы dense.
+	dirty := MakeSlice[uint32](a, 256, 256)
+	for i := range dirty {
+		dirty[i] = uint32(i % 4)
+	}
+	a.Reset()
+
+	s := NewSparseSet(a, 32)
+	members := map[uint32]bool{3: true, 10: true, 21: true, 30: true}
+	for x := range members {
+		s.Add(x)
+	}
+	for x := uint32(0); x < 32; x++ {
+		if s.Contains(x) != members[x] {
+			t.Fatalf("Contains(%d) = %v, want %v", x, s.Contains(x), members[x])
+		}
+	}
+}
+
+func TestSparseSetAddPanicsOutOfRange(t *testing.T) {
+	s := NewSparseSet(NewArena(256, 0), 8)
+	mustPanic(t, "key out of range", func() {
+		s.Add(8)
+	})
+}
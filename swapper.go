@@ -0,0 +1,105 @@
+package arena
+
+import "sync/atomic"
+
+// Swapper publishes read-mostly datasets built in their own arenas, RCU style.
+// Swapper публикует данные для чтения, построенные в отдельных аренах (в стиле RCU).
+//
+// A writer builds a new dataset in a fresh arena and publishes it atomically.
+// Readers Acquire the current version and Release it when done; an old
+// version's arena is handed to the retire callback only after it has been
+// replaced and its last reader has released it.
+type Swapper[T any] struct {
+	cur    atomic.Pointer[SwapperVersion[T]]
+	retire func(*Arena)
+}
+
+// SwapperVersion is one published dataset and its reader count.
+// SwapperVersion — одна опубликованная версия данных и счетчик ее читателей.
+type SwapperVersion[T any] struct {
+	value     *T
+	arena     *Arena
+	retire    func(*Arena)
+	readers   atomic.Int64
+	retired   atomic.Bool
+	reclaimed atomic.Bool
+}
+
+// NewSwapper creates an empty swapper. retire receives each arena once it is
+// safe to reuse (e.g. pool.Put); nil leaves old arenas to the GC.
+// NewSwapper создает пустой Swapper; retire получает арену, когда ее можно переиспользовать (например, pool.Put).
+func NewSwapper[T any](retire func(*Arena)) *Swapper[T] {
+	return &Swapper[T]{retire: retire}
+}
+
+// Publish makes value (allocated in a) the current version. The arena must
+// not be modified afterwards. / Publish делает value (из арены a) текущей версией; арену после этого менять нельзя.
+func (s *Swapper[T]) Publish(a *Arena, value *T) {
+	v := &SwapperVersion[T]{value: value, arena: a, retire: s.retire}
+	s.retireVersion(s.cur.Swap(v))
+}
+
+// Rebuild runs build on a and publishes the result. On error nothing is
+// published and a is passed to retire.
+// Rebuild вызывает build на арене a и публикует результат; при ошибке арена отдается в retire.
+func (s *Swapper[T]) Rebuild(a *Arena, build func(*Arena) (*T, error)) error {
+	value, err := build(a)
+	if err != nil {
+		if s.retire != nil {
+			s.retire(a)
+		}
+		return err
+	}
+	s.Publish(a, value)
+	return nil
+}
+
+// Acquire pins the current version; nil if nothing is published. Every
+// non-nil result must be released exactly once.
+// Acquire закрепляет текущую версию (nil, если ничего не опубликовано); каждую нужно ровно один раз освободить.
+func (s *Swapper[T]) Acquire() *SwapperVersion[T] {
+	for {
+		v := s.cur.Load()
+		if v == nil {
+			return nil
+		}
+		v.readers.Add(1)
+		// Recheck: if v was swapped out meanwhile, its arena may already be retired. / Перепроверка.
+		if s.cur.Load() == v {
+			return v
+		}
+		v.Release()
+	}
+}
+
+// Close unpublishes the current version and retires it once readers leave.
+// Close снимает текущую версию с публикации и освобождает ее после ухода читателей.
+func (s *Swapper[T]) Close() {
+	s.retireVersion(s.cur.Swap(nil))
+}
+
+func (s *Swapper[T]) retireVersion(v *SwapperVersion[T]) {
+	if v == nil {
+		return
+	}
+	v.retired.Store(true)
+	if v.readers.Load() == 0 {
+		v.reclaim()
+	}
+}
+
+// Value returns the pinned dataset; valid until Release. / Value возвращает закрепленные данные; валидны до Release.
+func (v *SwapperVersion[T]) Value() *T { return v.value }
+
+// Release unpins the version. / Release открепляет версию.
+func (v *SwapperVersion[T]) Release() {
+	if v.readers.Add(-1) == 0 && v.retired.Load() {
+		v.reclaim()
+	}
+}
+
+func (v *SwapperVersion[T]) reclaim() {
+	if v.reclaimed.CompareAndSwap(false, true) && v.retire != nil {
+		v.retire(v.arena)
+	}
+}
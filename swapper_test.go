@@ -0,0 +1,115 @@
+package arena
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type routeTable struct {
+	version int
+	name    string
+}
+
+func buildRoutes(version int, name string) func(*Arena) (*routeTable, error) {
+	return func(a *Arena) (*routeTable, error) {
+		t := New[routeTable](a)
+		*t = routeTable{version: version, name: a.AllocString(name)}
+		return t, nil
+	}
+}
+
+func TestSwapperRetiresAfterLastReader(t *testing.T) {
+	var retired []*Arena
+	s := NewSwapper[routeTable](func(a *Arena) { retired = append(retired, a) })
+	if s.Acquire() != nil {
+		t.Fatal("expected nil before first publish")
+	}
+
+	first := NewArena(256, 0)
+	if err := s.Rebuild(first, buildRoutes(1, "v1")); err != nil {
+		t.Fatal(err)
+	}
+	r := s.Acquire()
+	if r.Value().version != 1 || r.Value().name != "v1" {
+		t.Fatalf("unexpected value: %+v", r.Value())
+	}
+
+	_ = s.Rebuild(NewArena(256, 0), buildRoutes(2, "v2"))
+	if len(retired) != 0 {
+		t.Fatal("old arena retired while a reader still holds it")
+	}
+	if r.Value().name != "v1" {
+		t.Fatal("pinned version must stay readable")
+	}
+	r.Release()
+	if len(retired) != 1 || retired[0] != first {
+		t.Fatalf("expected first arena retired after release, got %d", len(retired))
+	}
+
+	r = s.Acquire()
+	if r.Value().version != 2 {
+		t.Fatalf("expected latest version, got %d", r.Value().version)
+	}
+	r.Release()
+	s.Close()
+	if len(retired) != 2 {
+		t.Fatalf("expected Close to retire the current arena, got %d", len(retired))
+	}
+}
+
+func TestSwapperRebuildErrorRetiresArena(t *testing.T) {
+	var retired int
+	s := NewSwapper[routeTable](func(*Arena) { retired++ })
+	errBuild := errors.New("bad config")
+	err := s.Rebuild(NewArena(64, 0), func(*Arena) (*routeTable, error) { return nil, errBuild })
+	if !errors.Is(err, errBuild) || retired != 1 || s.Acquire() != nil {
+		t.Fatalf("unexpected state: err=%v retired=%d", err, retired)
+	}
+}
+
+// TestSwapperConcurrent publishes while readers run; each arena must be
+// retired exactly once and never while pinned.
+// Run with: go test -race -run TestSwapperConcurrent
+func TestSwapperConcurrent(t *testing.T) {
+	pool := NewArenaPool(1024, 0)
+	var retiredCount atomic.Int64
+	s := NewSwapper[routeTable](func(a *Arena) {
+		retiredCount.Add(1)
+		pool.Put(a)
+	})
+	_ = s.Rebuild(pool.Get(), buildRoutes(0, "v"))
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				r := s.Acquire()
+				if r.Value().name != "v" {
+					t.Errorf("read retired data: %q", r.Value().name)
+				}
+				r.Release()
+			}
+		}()
+	}
+	const publishes = 500
+	for i := 1; i <= publishes; i++ {
+		_ = s.Rebuild(pool.Get(), buildRoutes(i, "v"))
+	}
+	close(stop)
+	wg.Wait()
+	s.Close()
+
+	if got := retiredCount.Load(); got != publishes+1 {
+		t.Fatalf("expected %d retirements, got %d", publishes+1, got)
+	}
+}
@@ -0,0 +1,622 @@
+package arena
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ThriftProtocol selects the Thrift wire encoding. / ThriftProtocol выбирает формат кодирования Thrift.
+type ThriftProtocol uint8
+
+const (
+	ThriftBinary  ThriftProtocol = iota // TBinaryProtocol.
+	ThriftCompact                       // TCompactProtocol.
+)
+
+// ThriftType is a Thrift field type (TType). / ThriftType — тип поля Thrift (TType).
+//
+// Values match the binary protocol; compact types are normalized to them.
+type ThriftType uint8
+
+const (
+	ThriftStop   ThriftType = 0
+	ThriftVoid   ThriftType = 1
+	ThriftBool   ThriftType = 2
+	ThriftByte   ThriftType = 3
+	ThriftDouble ThriftType = 4
+	ThriftI16    ThriftType = 6
+	ThriftI32    ThriftType = 8
+	ThriftI64    ThriftType = 10
+	ThriftString ThriftType = 11
+	ThriftStruct ThriftType = 12
+	ThriftMap    ThriftType = 13
+	ThriftSet    ThriftType = 14
+	ThriftList   ThriftType = 15
+	ThriftUUID   ThriftType = 16
+)
+
+// ThriftMessageType is a Thrift message kind. / ThriftMessageType — вид сообщения Thrift.
+type ThriftMessageType uint8
+
+const (
+	ThriftCall      ThriftMessageType = 1
+	ThriftReply     ThriftMessageType = 2
+	ThriftException ThriftMessageType = 3
+	ThriftOneway    ThriftMessageType = 4
+)
+
+var (
+	// ErrThriftShortBuffer is returned when input ends mid-value. / ErrThriftShortBuffer — вход закончился посреди значения.
+	ErrThriftShortBuffer = errors.New("arena: thrift: unexpected end of input")
+	// ErrThriftInvalidData is returned for malformed input. / ErrThriftInvalidData — некорректные входные данные.
+	ErrThriftInvalidData = errors.New("arena: thrift: invalid data")
+)
+
+const (
+	thriftBinaryVersionMask = 0xffff0000
+	thriftBinaryVersion1    = 0x80010000
+	thriftCompactProtocolID = 0x82
+	thriftCompactVersion    = 1
+	thriftMaxSkipDepth      = 64
+)
+
+// compactToThriftType maps compact protocol type ids to TType. / compactToThriftType переводит типы compact-протокола в TType.
+var compactToThriftType = [...]ThriftType{
+	0:  ThriftStop,
+	1:  ThriftBool, // BOOLEAN_TRUE
+	2:  ThriftBool, // BOOLEAN_FALSE
+	3:  ThriftByte,
+	4:  ThriftI16,
+	5:  ThriftI32,
+	6:  ThriftI64,
+	7:  ThriftDouble,
+	8:  ThriftString,
+	9:  ThriftList,
+	10: ThriftSet,
+	11: ThriftMap,
+	12: ThriftStruct,
+	13: ThriftUUID,
+}
+
+// ThriftReader decodes Thrift binary or compact data with strings,
+// binaries and containers allocated from an arena.
+// ThriftReader декодирует Thrift (binary или compact), выделяя строки, бинарные данные и контейнеры в арене.
+//
+// The reader mirrors the read half of TProtocol, so generated-code style
+// decoders can be ported by swapping the protocol calls. Values returned
+// from it are valid until the arena is Reset or returned to the pool.
+type ThriftReader struct {
+	a     *Arena
+	buf   []byte
+	pos   int
+	proto ThriftProtocol
+
+	// Compact protocol state. / Состояние compact-протокола.
+	lastFieldID int16
+	fieldStack  []int16
+	boolPending bool
+	boolValue   bool
+}
+
+// NewThriftReader creates a reader over data. / NewThriftReader создает читатель поверх data.
+func NewThriftReader(a *Arena, proto ThriftProtocol, data []byte) *ThriftReader {
+	if a == nil {
+		panic("arena: NewThriftReader called with nil arena")
+	}
+	if proto != ThriftBinary && proto != ThriftCompact {
+		panic("arena: unknown Thrift protocol")
+	}
+	return &ThriftReader{a: a, buf: data, proto: proto}
+}
+
+// Reset points the reader at new input, keeping arena and protocol. / Reset переключает читатель на новый вход.
+func (r *ThriftReader) Reset(data []byte) {
+	r.buf = data
+	r.pos = 0
+	r.lastFieldID = 0
+	r.fieldStack = r.fieldStack[:0]
+	r.boolPending = false
+}
+
+// Remaining returns the number of unread bytes. / Remaining возвращает число непрочитанных байт.
+func (r *ThriftReader) Remaining() int {
+	return len(r.buf) - r.pos
+}
+
+// ReadMessageBegin reads a message header. / ReadMessageBegin читает заголовок сообщения.
+func (r *ThriftReader) ReadMessageBegin() (name string, typ ThriftMessageType, seqID int32, err error) {
+	if r.proto == ThriftCompact {
+		id, err := r.readByte()
+		if err != nil {
+			return "", 0, 0, err
+		}
+		if id != thriftCompactProtocolID {
+			return "", 0, 0, ErrThriftInvalidData
+		}
+		vt, err := r.readByte()
+		if err != nil {
+			return "", 0, 0, err
+		}
+		if vt&0x1f != thriftCompactVersion {
+			return "", 0, 0, ErrThriftInvalidData
+		}
+		seq, err := r.readUvarint()
+		if err != nil {
+			return "", 0, 0, err
+		}
+		name, err = r.ReadString()
+		return name, ThriftMessageType(vt >> 5), int32(seq), err
+	}
+
+	size, err := r.ReadI32()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if size < 0 {
+		if uint32(size)&thriftBinaryVersionMask != thriftBinaryVersion1 {
+			return "", 0, 0, ErrThriftInvalidData
+		}
+		name, err = r.ReadString()
+		if err != nil {
+			return "", 0, 0, err
+		}
+		seqID, err = r.ReadI32()
+		return name, ThriftMessageType(size & 0xff), seqID, err
+	}
+
+	// Old non-strict header: name length was already consumed. / Старый нестрогий заголовок.
+	b, err := r.next(int(size))
+	if err != nil {
+		return "", 0, 0, err
+	}
+	name = r.a.AllocBytesToString(b)
+	t, err := r.readByte()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	seqID, err = r.ReadI32()
+	return name, ThriftMessageType(t), seqID, err
+}
+
+// ReadMessageEnd reads a message trailer (no-op on the wire). / ReadMessageEnd завершает сообщение.
+func (r *ThriftReader) ReadMessageEnd() error { return nil }
+
+// ReadStructBegin enters a struct. / ReadStructBegin входит в структуру.
+func (r *ThriftReader) ReadStructBegin() error {
+	if r.proto == ThriftCompact {
+		r.fieldStack = append(r.fieldStack, r.lastFieldID)
+		r.lastFieldID = 0
+	}
+	return nil
+}
+
+// ReadStructEnd leaves a struct. / ReadStructEnd выходит из структуры.
+func (r *ThriftReader) ReadStructEnd() error {
+	if r.proto == ThriftCompact {
+		n := len(r.fieldStack)
+		if n == 0 {
+			return ErrThriftInvalidData
+		}
+		r.lastFieldID = r.fieldStack[n-1]
+		r.fieldStack = r.fieldStack[:n-1]
+	}
+	return nil
+}
+
+// ReadFieldBegin reads a field header; typ is ThriftStop after the last field.
+// ReadFieldBegin читает заголовок поля; после последнего поля typ равен ThriftStop.
+func (r *ThriftReader) ReadFieldBegin() (typ ThriftType, id int16, err error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	if r.proto == ThriftBinary {
+		if ThriftType(b) == ThriftStop {
+			return ThriftStop, 0, nil
+		}
+		id, err = r.ReadI16()
+		return ThriftType(b), id, err
+	}
+
+	if b == 0 {
+		return ThriftStop, 0, nil
+	}
+	ct := b & 0x0f
+	if delta := int16(b >> 4); delta != 0 {
+		id = r.lastFieldID + delta
+	} else {
+		id, err = r.ReadI16()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	typ, err = compactType(ct)
+	if err != nil {
+		return 0, 0, err
+	}
+	if typ == ThriftBool {
+		r.boolPending = true
+		r.boolValue = ct == 1
+	}
+	r.lastFieldID = id
+	return typ, id, nil
+}
+
+// ReadFieldEnd finishes a field (no-op on the wire). / ReadFieldEnd завершает поле.
+func (r *ThriftReader) ReadFieldEnd() error { return nil }
+
+// ReadMapBegin reads a map header. / ReadMapBegin читает заголовок map.
+func (r *ThriftReader) ReadMapBegin() (keyType, valueType ThriftType, size int, err error) {
+	if r.proto == ThriftBinary {
+		kt, err := r.readByte()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		vt, err := r.readByte()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		size, err = r.readBinarySize()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return ThriftType(kt), ThriftType(vt), size, nil
+	}
+
+	size, err = r.readCompactSize()
+	if err != nil || size == 0 {
+		return 0, 0, 0, err
+	}
+	kv, err := r.readByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if keyType, err = compactType(kv >> 4); err != nil {
+		return 0, 0, 0, err
+	}
+	if valueType, err = compactType(kv & 0x0f); err != nil {
+		return 0, 0, 0, err
+	}
+	return keyType, valueType, size, nil
+}
+
+// ReadMapEnd finishes a map (no-op on the wire). / ReadMapEnd завершает map.
+func (r *ThriftReader) ReadMapEnd() error { return nil }
+
+// ReadListBegin reads a list header. / ReadListBegin читает заголовок списка.
+func (r *ThriftReader) ReadListBegin() (elemType ThriftType, size int, err error) {
+	if r.proto == ThriftBinary {
+		et, err := r.readByte()
+		if err != nil {
+			return 0, 0, err
+		}
+		size, err = r.readBinarySize()
+		return ThriftType(et), size, err
+	}
+
+	b, err := r.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	size = int(b >> 4)
+	if size == 15 {
+		if size, err = r.readCompactSize(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if size > r.Remaining() {
+		return 0, 0, ErrThriftInvalidData
+	}
+	elemType, err = compactType(b & 0x0f)
+	return elemType, size, err
+}
+
+// ReadListEnd finishes a list (no-op on the wire). / ReadListEnd завершает список.
+func (r *ThriftReader) ReadListEnd() error { return nil }
+
+// ReadSetBegin reads a set header. / ReadSetBegin читает заголовок множества.
+func (r *ThriftReader) ReadSetBegin() (elemType ThriftType, size int, err error) {
+	return r.ReadListBegin()
+}
+
+// ReadSetEnd finishes a set (no-op on the wire). / ReadSetEnd завершает множество.
+func (r *ThriftReader) ReadSetEnd() error { return nil }
+
+// ReadBool reads a bool. / ReadBool читает bool.
+func (r *ThriftReader) ReadBool() (bool, error) {
+	if r.boolPending {
+		r.boolPending = false
+		return r.boolValue, nil
+	}
+	b, err := r.readByte()
+	return b == 1, err
+}
+
+// ReadI8 reads an i8. / ReadI8 читает i8.
+func (r *ThriftReader) ReadI8() (int8, error) {
+	b, err := r.readByte()
+	return int8(b), err
+}
+
+// ReadI16 reads an i16. / ReadI16 читает i16.
+func (r *ThriftReader) ReadI16() (int16, error) {
+	if r.proto == ThriftCompact {
+		v, err := r.readZigzag()
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return 0, ErrThriftInvalidData
+		}
+		return int16(v), err
+	}
+	b, err := r.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
+}
+
+// ReadI32 reads an i32. / ReadI32 читает i32.
+func (r *ThriftReader) ReadI32() (int32, error) {
+	if r.proto == ThriftCompact {
+		v, err := r.readZigzag()
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return 0, ErrThriftInvalidData
+		}
+		return int32(v), err
+	}
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+// ReadI64 reads an i64. / ReadI64 читает i64.
+func (r *ThriftReader) ReadI64() (int64, error) {
+	if r.proto == ThriftCompact {
+		return r.readZigzag()
+	}
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+// ReadDouble reads a double. / ReadDouble читает double.
+func (r *ThriftReader) ReadDouble() (float64, error) {
+	b, err := r.next(8)
+	if err != nil {
+		return 0, err
+	}
+	if r.proto == ThriftCompact {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+}
+
+// ReadString reads a string into the arena. / ReadString читает строку в арену.
+func (r *ThriftReader) ReadString() (string, error) {
+	b, err := r.readBinaryView()
+	if err != nil {
+		return "", err
+	}
+	return r.a.AllocBytesToString(b), nil
+}
+
+// ReadBinary reads a binary value into the arena. / ReadBinary читает бинарное значение в арену.
+func (r *ThriftReader) ReadBinary() ([]byte, error) {
+	b, err := r.readBinaryView()
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	out := r.a.AllocBytes(len(b))
+	copy(out, b)
+	return out, nil
+}
+
+// ReadUUID reads a 16-byte UUID. / ReadUUID читает UUID из 16 байт.
+func (r *ThriftReader) ReadUUID() ([16]byte, error) {
+	var u [16]byte
+	b, err := r.next(16)
+	if err != nil {
+		return u, err
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// Skip reads and discards a value of type typ. / Skip читает и отбрасывает значение типа typ.
+func (r *ThriftReader) Skip(typ ThriftType) error {
+	return r.skip(typ, 0)
+}
+
+func (r *ThriftReader) skip(typ ThriftType, depth int) error {
+	if depth > thriftMaxSkipDepth {
+		return ErrThriftInvalidData
+	}
+	var err error
+	switch typ {
+	case ThriftBool:
+		_, err = r.ReadBool()
+	case ThriftByte:
+		_, err = r.readByte()
+	case ThriftI16:
+		_, err = r.ReadI16()
+	case ThriftI32:
+		_, err = r.ReadI32()
+	case ThriftI64:
+		_, err = r.ReadI64()
+	case ThriftDouble:
+		_, err = r.next(8)
+	case ThriftString:
+		_, err = r.readBinaryView()
+	case ThriftUUID:
+		_, err = r.next(16)
+	case ThriftStruct:
+		if err = r.ReadStructBegin(); err != nil {
+			return err
+		}
+		for {
+			ft, _, err := r.ReadFieldBegin()
+			if err != nil {
+				return err
+			}
+			if ft == ThriftStop {
+				break
+			}
+			if err = r.skip(ft, depth+1); err != nil {
+				return err
+			}
+		}
+		err = r.ReadStructEnd()
+	case ThriftMap:
+		kt, vt, n, err := r.ReadMapBegin()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err = r.skip(kt, depth+1); err != nil {
+				return err
+			}
+			if err = r.skip(vt, depth+1); err != nil {
+				return err
+			}
+		}
+	case ThriftList, ThriftSet:
+		et, n, err := r.ReadListBegin()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err = r.skip(et, depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		err = ErrThriftInvalidData
+	}
+	return err
+}
+
+// ThriftReadList reads a list (or set) with elements allocated in the arena.
+// ThriftReadList читает список (или множество), размещая элементы в арене.
+func ThriftReadList[T any](r *ThriftReader, readElem func(*ThriftReader) (T, error)) ([]T, error) {
+	_, n, err := r.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+	out := MakeSlice[T](r.a, n, n)
+	for i := range out {
+		if out[i], err = readElem(r); err != nil {
+			return nil, err
+		}
+	}
+	return out, r.ReadListEnd()
+}
+
+// ThriftReadMap reads a map into parallel key/value slices in the arena.
+// ThriftReadMap читает map в параллельные слайсы ключей и значений в арене.
+func ThriftReadMap[K, V any](r *ThriftReader, readKey func(*ThriftReader) (K, error), readValue func(*ThriftReader) (V, error)) ([]K, []V, error) {
+	_, _, n, err := r.ReadMapBegin()
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := MakeSlice[K](r.a, n, n)
+	values := MakeSlice[V](r.a, n, n)
+	for i := 0; i < n; i++ {
+		if keys[i], err = readKey(r); err != nil {
+			return nil, nil, err
+		}
+		if values[i], err = readValue(r); err != nil {
+			return nil, nil, err
+		}
+	}
+	return keys, values, r.ReadMapEnd()
+}
+
+func compactType(ct byte) (ThriftType, error) {
+	if int(ct) >= len(compactToThriftType) {
+		return 0, ErrThriftInvalidData
+	}
+	return compactToThriftType[ct], nil
+}
+
+func (r *ThriftReader) next(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, ErrThriftInvalidData
+	}
+	if n > len(r.buf)-r.pos {
+		return nil, ErrThriftShortBuffer
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *ThriftReader) readByte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, ErrThriftShortBuffer
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *ThriftReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n == 0 {
+		return 0, ErrThriftShortBuffer
+	}
+	if n < 0 {
+		return 0, ErrThriftInvalidData
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *ThriftReader) readZigzag() (int64, error) {
+	u, err := r.readUvarint()
+	return int64(u>>1) ^ -int64(u&1), err
+}
+
+// readBinarySize reads an i32 container size and sanity-checks it against the input.
+// readBinarySize читает размер контейнера и сверяет его с остатком входа.
+func (r *ThriftReader) readBinarySize() (int, error) {
+	n, err := r.ReadI32()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || int(n) > r.Remaining() {
+		return 0, ErrThriftInvalidData
+	}
+	return int(n), nil
+}
+
+func (r *ThriftReader) readCompactSize() (int, error) {
+	n, err := r.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(r.Remaining()) {
+		return 0, ErrThriftInvalidData
+	}
+	return int(n), nil
+}
+
+// readBinaryView returns the raw bytes of a string/binary without copying.
+// readBinaryView возвращает байты string/binary без копирования.
+func (r *ThriftReader) readBinaryView() ([]byte, error) {
+	if r.proto == ThriftCompact {
+		n, err := r.readCompactSize()
+		if err != nil {
+			return nil, err
+		}
+		return r.next(n)
+	}
+	n, err := r.ReadI32()
+	if err != nil {
+		return nil, err
+	}
+	return r.next(int(n))
+}
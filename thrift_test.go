@@ -0,0 +1,137 @@
+package arena
+
+import (
+	"errors"
+	"testing"
+)
+
+// thriftBinarySample encodes a CALL "ping" (seq 7) whose args struct holds
+// field 1: string "hi", field 2: i32 -5, field 3: list<i64>{1, 300}, field 4: bool true.
+var thriftBinarySample = []byte{
+	0x80, 0x01, 0x00, 0x01, // version | CALL
+	0x00, 0x00, 0x00, 0x04, 'p', 'i', 'n', 'g',
+	0x00, 0x00, 0x00, 0x07, // seqid
+	0x0b, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 'h', 'i',
+	0x08, 0x00, 0x02, 0xff, 0xff, 0xff, 0xfb,
+	0x0f, 0x00, 0x03, 0x0a, 0x00, 0x00, 0x00, 0x02,
+	0, 0, 0, 0, 0, 0, 0, 1,
+	0, 0, 0, 0, 0, 0, 0x01, 0x2c,
+	0x02, 0x00, 0x04, 0x01,
+	0x00, // STOP
+}
+
+// thriftCompactSample is the same message in the compact protocol.
+var thriftCompactSample = []byte{
+	0x82, 0x21, 0x07, 0x04, 'p', 'i', 'n', 'g',
+	0x18, 0x02, 'h', 'i', // delta 1, BINARY
+	0x15, 0x09, // delta 1, I32, zigzag(-5)
+	0x19, 0x26, 0x02, 0xd8, 0x04, // delta 1, LIST, 2 x I64, zigzag(1), zigzag(300)
+	0x11, // delta 1, BOOLEAN_TRUE
+	0x00,
+}
+
+func decodeThriftPing(t *testing.T, r *ThriftReader) {
+	t.Helper()
+	name, typ, seq, err := r.ReadMessageBegin()
+	if err != nil {
+		t.Fatalf("ReadMessageBegin: %v", err)
+	}
+	if name != "ping" || typ != ThriftCall || seq != 7 {
+		t.Fatalf("unexpected header: got %q/%d/%d", name, typ, seq)
+	}
+	if err := r.ReadStructBegin(); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		s    string
+		i    int32
+		list []int64
+		flag bool
+	)
+	for {
+		ft, id, err := r.ReadFieldBegin()
+		if err != nil {
+			t.Fatalf("ReadFieldBegin: %v", err)
+		}
+		if ft == ThriftStop {
+			break
+		}
+		switch id {
+		case 1:
+			s, err = r.ReadString()
+		case 2:
+			i, err = r.ReadI32()
+		case 3:
+			list, err = ThriftReadList(r, (*ThriftReader).ReadI64)
+		case 4:
+			flag, err = r.ReadBool()
+		default:
+			err = r.Skip(ft)
+		}
+		if err != nil {
+			t.Fatalf("field %d: %v", id, err)
+		}
+	}
+	if err := r.ReadStructEnd(); err != nil {
+		t.Fatal(err)
+	}
+	if s != "hi" || i != -5 || !flag {
+		t.Fatalf("unexpected fields: s=%q i=%d flag=%v", s, i, flag)
+	}
+	if len(list) != 2 || list[0] != 1 || list[1] != 300 {
+		t.Fatalf("unexpected list: %v", list)
+	}
+	if r.Remaining() != 0 {
+		t.Fatalf("expected input to be consumed, %d bytes left", r.Remaining())
+	}
+}
+
+func TestThriftReaderBinary(t *testing.T) {
+	a := NewArena(256, 0)
+	decodeThriftPing(t, NewThriftReader(a, ThriftBinary, thriftBinarySample))
+	if a.UsedBytes() == 0 {
+		t.Fatal("expected decoded values to be allocated in the arena")
+	}
+}
+
+func TestThriftReaderCompact(t *testing.T) {
+	a := NewArena(256, 0)
+	decodeThriftPing(t, NewThriftReader(a, ThriftCompact, thriftCompactSample))
+}
+
+func TestThriftReaderSkipStruct(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		proto ThriftProtocol
+		data  []byte
+	}{
+		{"binary", ThriftBinary, thriftBinarySample},
+		{"compact", ThriftCompact, thriftCompactSample},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewThriftReader(NewArena(256, 0), tc.proto, tc.data)
+			if _, _, _, err := r.ReadMessageBegin(); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Skip(ThriftStruct); err != nil {
+				t.Fatalf("Skip: %v", err)
+			}
+			if r.Remaining() != 0 {
+				t.Fatalf("expected input to be consumed, %d bytes left", r.Remaining())
+			}
+		})
+	}
+}
+
+func TestThriftReaderTruncatedInput(t *testing.T) {
+	a := NewArena(256, 0)
+	for n := 0; n < len(thriftBinarySample)-1; n++ {
+		r := NewThriftReader(a, ThriftBinary, thriftBinarySample[:n])
+		if _, _, _, err := r.ReadMessageBegin(); err != nil {
+			continue
+		}
+		if err := r.Skip(ThriftStruct); !errors.Is(err, ErrThriftShortBuffer) && !errors.Is(err, ErrThriftInvalidData) {
+			t.Fatalf("prefix %d: expected decode error, got %v", n, err)
+		}
+	}
+}